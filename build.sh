@@ -3,14 +3,21 @@
 # Ensure the 'build' folder exists
 mkdir -p build
 
+# Stamp the version from the latest git tag, keeping the one in config/config.go otherwise
+LDFLAGS="-s -w"
+VERSION=$(git describe --tags --abbrev=0 2>/dev/null)
+if [ -n "$VERSION" ]; then
+  LDFLAGS="$LDFLAGS -X goi/config.CLI_VERSION=${VERSION#v}"
+fi
+
 # Build for the current platform (local)
-go build -ldflags="-s -w" -trimpath -o build/goi .
+go build -ldflags="$LDFLAGS" -trimpath -o build/goi .
 
 # Build for Linux (64-bit)
-GOOS=linux GOARCH=amd64 go build -ldflags="-s -w" -o build/goi-linux .
+GOOS=linux GOARCH=amd64 go build -ldflags="$LDFLAGS" -o build/goi-linux .
 
 # Build for macOS (64-bit)
-GOOS=darwin GOARCH=amd64 go build -ldflags="-s -w" -o build/goi-macos .
+GOOS=darwin GOARCH=amd64 go build -ldflags="$LDFLAGS" -o build/goi-macos .
 
 # Build for Windows (64-bit) (Note: must specify .exe for Windows)
-GOOS=windows GOARCH=amd64 go build -ldflags="-s -w" -o build/goi-win.exe .
+GOOS=windows GOARCH=amd64 go build -ldflags="$LDFLAGS" -o build/goi-win.exe .
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// astBuilder constructs the syntax nodes that generators add to an existing file.
+// Every node gets the same position, the place it is inserted at, so go/printer keeps
+// the file's comments before or after the new code instead of moving them into it.
+type astBuilder struct {
+	pos token.Pos
+}
+
+// ident returns an identifier
+func (b astBuilder) ident(name string) *ast.Ident {
+	return &ast.Ident{NamePos: b.pos, Name: name}
+}
+
+// expr returns an identifier or a selector expression for a dotted name such as c.UserHandler
+func (b astBuilder) expr(name string) ast.Expr {
+	parts := strings.Split(name, ".")
+	var x ast.Expr = b.ident(parts[0])
+	for _, sel := range parts[1:] {
+		x = &ast.SelectorExpr{X: x, Sel: b.ident(sel)}
+	}
+	return x
+}
+
+// star returns a pointer type such as *handlers.UserHandler
+func (b astBuilder) star(x ast.Expr) *ast.StarExpr {
+	return &ast.StarExpr{Star: b.pos, X: x}
+}
+
+// str returns a string literal
+func (b astBuilder) str(value string) *ast.BasicLit {
+	return &ast.BasicLit{ValuePos: b.pos, Kind: token.STRING, Value: strconv.Quote(value)}
+}
+
+// call returns a call of fun with the given arguments
+func (b astBuilder) call(fun ast.Expr, args ...ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{Fun: fun, Lparen: b.pos, Args: args, Rparen: b.pos}
+}
+
+// assign returns lhs = rhs, or lhs := rhs when tok is token.DEFINE
+func (b astBuilder) assign(lhs ast.Expr, tok token.Token, rhs ast.Expr) *ast.AssignStmt {
+	return &ast.AssignStmt{Lhs: []ast.Expr{lhs}, TokPos: b.pos, Tok: tok, Rhs: []ast.Expr{rhs}}
+}
+
+// field returns a struct field or parameter declaration
+func (b astBuilder) field(name string, typ ast.Expr) *ast.Field {
+	return &ast.Field{Names: []*ast.Ident{b.ident(name)}, Type: typ}
+}
+
+// funcLit returns a function literal without results
+func (b astBuilder) funcLit(params []*ast.Field, body []ast.Stmt) *ast.FuncLit {
+	return &ast.FuncLit{
+		Type: &ast.FuncType{Func: b.pos, Params: &ast.FieldList{Opening: b.pos, List: params, Closing: b.pos}},
+		Body: &ast.BlockStmt{Lbrace: b.pos, List: body, Rbrace: b.pos},
+	}
+}
+
+// statementsPos returns where statements are added to a function body: before its final return, or at its end
+func statementsPos(body *ast.BlockStmt) token.Pos {
+	if n := len(body.List); n > 0 {
+		if ret, ok := body.List[n-1].(*ast.ReturnStmt); ok {
+			return ret.Pos()
+		}
+	}
+	return body.Rbrace
+}
+
+// insertStatements adds statements to a function body before its final return, or at its end
+func insertStatements(body *ast.BlockStmt, stmts []ast.Stmt) {
+	at := len(body.List)
+	if at > 0 {
+		if _, ok := body.List[at-1].(*ast.ReturnStmt); ok {
+			at--
+		}
+	}
+	list := make([]ast.Stmt, 0, len(body.List)+len(stmts))
+	list = append(list, body.List[:at]...)
+	list = append(list, stmts...)
+	body.List = append(list, body.List[at:]...)
+}
+
+// printGoFile prints an edited syntax tree with go/format and checks that the result is valid Go
+func printGoFile(path string, fset *token.FileSet, file *ast.File) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", path, err)
+	}
+	return formatGeneratedSource(path, buf.Bytes())
+}
@@ -6,7 +6,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath" // For joining paths safely
-	"time"          // Add time package for timestamp generation
+	"strings"
+	"time" // Add time package for timestamp generation
 
 	"github.com/spf13/cobra"
 )
@@ -25,8 +26,18 @@ var MySQLBackupCmd = &cobra.Command{
 	Long: `The 'backup' command runs a MySQL dump command to create a backup of the database.
 
 You can specify the path to save the backup file using the --path or -p flag.
-You can also specify the MySQL credentials using the --user, --password, and --database flags.`,
+You can also specify the MySQL credentials using the --user, --password, and --database flags.
+Credentials that are not given fall back to the DB_* variables of the environment or .env,
+then to the database section of goi.yaml.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := loadEnvFile(defaultEnvFile); err != nil {
+			return err
+		}
+		db, err := mysqlCredentials()
+		if err != nil {
+			return err
+		}
+
 		// Get the current directory where the command is executed
 		projectDir, err := os.Getwd()
 		if err != nil {
@@ -42,7 +53,7 @@ You can also specify the MySQL credentials using the --user, --password, and --d
 
 		// Create a timestamp for the backup file
 		timestamp := fmt.Sprintf("%d", time.Now().Unix())
-		backupFile := fmt.Sprintf("%s_backup_%s.sql", mysqlDatabase, timestamp)
+		backupFile := fmt.Sprintf("%s_backup_%s.sql", db.Name, timestamp)
 
 		// Ensure the backup directory exists
 		if err := os.MkdirAll(backupDir, os.ModePerm); err != nil {
@@ -52,21 +63,11 @@ You can also specify the MySQL credentials using the --user, --password, and --d
 		// Full backup file path
 		fullBackupPath := filepath.Join(backupDir, backupFile)
 
-		// Validate MySQL credentials
-		if mysqlUser == "" || mysqlPassword == "" || mysqlDatabase == "" {
-			return fmt.Errorf("MySQL credentials are required: --user, --password, --database")
-		}
-
-		// Run the mysqldump command
-		cmdArgs := []string{
-			"-u", mysqlUser,
-			"-p" + mysqlPassword, // Don't forget to use password directly after -p
-			"-B", mysqlDatabase,   // Use the -B flag to specify the database
-			"--result-file=" + fullBackupPath,
-		}
-
 		// Build the 'mysqldump' command
-		backupCmd := exec.Command("mysqldump", cmdArgs...)
+		backupCmd := mysqlClientCommand("mysqldump", db,
+			"-B", db.Name, // Use the -B flag to specify the database
+			"--result-file="+fullBackupPath,
+		)
 		backupCmd.Dir = projectDir // Ensure command runs from the project root
 
 		// Pipe command output to current terminal
@@ -93,11 +94,16 @@ var MySQLRestoreCmd = &cobra.Command{
 	Long: `The 'restore' command restores a MySQL database from a backup file.
 
 You can specify the backup file to restore from using the --path or -p flag.
-You can also specify the MySQL credentials using the --user, --password, and --database flags.`,
+You can also specify the MySQL credentials using the --user, --password, and --database flags.
+Credentials that are not given fall back to the DB_* variables of the environment or .env,
+then to the database section of goi.yaml.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Validate MySQL credentials
-		if mysqlUser == "" || mysqlPassword == "" || mysqlDatabase == "" {
-			return fmt.Errorf("MySQL credentials are required: --user, --password, --database")
+		if err := loadEnvFile(defaultEnvFile); err != nil {
+			return err
+		}
+		db, err := mysqlCredentials()
+		if err != nil {
+			return err
 		}
 
 		// Validate restore file path
@@ -110,20 +116,19 @@ You can also specify the MySQL credentials using the --user, --password, and --d
 			return fmt.Errorf("backup file does not exist: %s", restorePath)
 		}
 
-		// Run the mysql command to restore the database
-		cmdArgs := []string{
-			"-u", mysqlUser,
-			"-p" + mysqlPassword, // Don't forget to use password directly after -p
-			mysqlDatabase,         // Specify the database to restore to
-			"<", restorePath,      // Use input redirection to load the backup
+		// The backup is fed to the client on stdin, as the shell would with < backup.sql
+		backup, err := os.Open(restorePath)
+		if err != nil {
+			return fmt.Errorf("failed to open backup file: %w", err)
 		}
+		defer backup.Close()
 
 		// Build the 'mysql' command
-		restoreCmd := exec.Command("mysql", cmdArgs...)
+		restoreCmd := mysqlClientCommand("mysql", db, db.Name) // Specify the database to restore to
 		// Pipe command output to current terminal
 		restoreCmd.Stdout = os.Stdout
 		restoreCmd.Stderr = os.Stderr
-		restoreCmd.Stdin = os.Stdin // Allow input if needed
+		restoreCmd.Stdin = backup
 
 		// Run the command
 		utils.PrintInfo(fmt.Sprintf("Restoring MySQL database from: %s", restorePath))
@@ -137,6 +142,49 @@ You can also specify the MySQL credentials using the --user, --password, and --d
 	},
 }
 
+// mysqlCredentials returns the connection settings of backup and restore.
+// Flags take precedence over the environment, .env and goi.yaml.
+func mysqlCredentials() (databaseConfig, error) {
+	db, err := readDatabaseConfig(defaultEnvFile)
+	if err != nil {
+		return db, err
+	}
+	if mysqlUser != "" {
+		db.User = mysqlUser
+	}
+	if mysqlPassword != "" {
+		db.Password = mysqlPassword
+	}
+	if mysqlDatabase != "" {
+		db.Name = mysqlDatabase
+	}
+	return db, checkMySQLCredentials(db)
+}
+
+// checkMySQLCredentials reports the connection settings that backup and restore cannot run without.
+// The password may be empty, e.g. for a local server without one.
+func checkMySQLCredentials(db databaseConfig) error {
+	var missing []string
+	for _, setting := range []struct{ key, value string }{{"DB_HOST", db.Host}, {"DB_USER", db.User}, {"DB_NAME", db.Name}} {
+		if setting.value == "" {
+			missing = append(missing, setting.key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("MySQL credentials are incomplete, missing %s: set DB_HOST, DB_USER and DB_NAME in %s or the environment (DB_PASSWORD may be empty), or pass --user and --database",
+			strings.Join(missing, ", "), defaultEnvFile)
+	}
+	return nil
+}
+
+// mysqlClientCommand builds a mysqldump or mysql command connected with the given settings.
+// Passing the password through the environment keeps it out of the process list, and an empty one does not prompt.
+func mysqlClientCommand(program string, db databaseConfig, args ...string) *exec.Cmd {
+	cmd := exec.Command(program, append([]string{"-h", db.Host, "-P", db.Port, "-u", db.User}, args...)...)
+	cmd.Env = append(os.Environ(), "MYSQL_PWD="+db.Password)
+	return cmd
+}
+
 func init() {
 	MySQLBackupCmd.Flags().StringVarP(&backupPath, "path", "b", "", "Path to save the backup file (e.g., /path/to/save/backup/)")
 	MySQLBackupCmd.Flags().StringVarP(&mysqlUser, "user", "u", "", "MySQL username (e.g., root)")
@@ -4,114 +4,217 @@ import (
 	"fmt"
 	"goi/utils"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"runtime"
 
 	"github.com/spf13/cobra"
 )
 
+// buildAllCmds builds every main package of the module
+var buildAllCmds bool
+
 var BuildCmd = &cobra.Command{
-	Use:   "build",
+	Use:   "build [name]",
 	Short: "Build the project for the specified platform(s)",
-	RunE:  runBuildCommand,
+	Long: `The 'build' command compiles the project for the current platform or the selected ones.
+
+Targets are os/arch pairs given with --target (see 'go tool dist list'); --linux,
+--mac, --windows and --all select those systems on the host architecture. Each
+artifact is named <binary>-<os>-<arch> (.exe on Windows) and the targets are built
+in parallel by --parallel workers, followed by a summary table.
+
+When the project has a version package (see 'goi make version', placed by
+build.version_package of goi.yaml) its Version, Commit, BuildDate and Dirty
+variables are set through -ldflags -X: the version is the latest git tag unless
+--version is given, and the build date honours SOURCE_DATE_EPOCH.
+
+The main package, output directory and binary name come from the main and build
+settings of goi.yaml, defaulting to the module root, build/ and the last element
+of the module path.
+
+When the module has several 'package main' directories (cmd/api, cmd/worker, ...)
+name the one to build, or build all of them with --all-cmds. Binaries of other
+packages than the project's main one are named after their directory. Without a
+name and a main setting in goi.yaml you pick the package from a list (outside a
+terminal cmd/api, internal/server or the module root is built).`,
+	Example: `  goi build
+  goi build --target linux/amd64,linux/arm64,darwin/arm64,windows/amd64
+  goi build --version v1.4.0
+  goi build worker --linux
+  goi build --all-cmds --all`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBuildCommand,
 }
 
 func runBuildCommand(cmd *cobra.Command, args []string) error {
+	// Package, output directory and binary name come from goi.yaml
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	// Stamp the version, commit, build date and dirty state into the version package
+	info, err := currentBuildInfo(buildVersion)
+	if err != nil {
+		return err
+	}
+	stamps, err := versionLDFlags(config, info)
+	if err != nil {
+		return err
+	}
+	ldflags := "-s -w" // Reduce binary size
+	if stamps != "" {
+		ldflags += " " + stamps
+		utils.PrintInfo(fmt.Sprintf("Stamping version %s (%s) into %s", info.Version, info.Commit, config.Build.VersionPackage))
+	}
+
 	// Set default build flags
 	buildFlags := []string{
-		"-ldflags", "-s -w", // Reduce binary size
-		"-trimpath",         // Remove file paths from binary
+		"-ldflags", ldflags,
+		"-trimpath", // Remove file paths from binary
+	}
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	// Default output name for the binary (for the current machine)
-	platforms := []string{} // Initialize an empty slice
+	// Select the main packages to build
+	var packages []mainPackage
+	if buildAllCmds {
+		if len(args) > 0 {
+			return fmt.Errorf("a package name cannot be combined with --all-cmds")
+		}
+		packages, err = discoverMainPackages(projectDir, config)
+		if err != nil {
+			return err
+		}
+		if len(packages) == 0 {
+			return fmt.Errorf("no main package found in %s", projectDir)
+		}
+	} else {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		pkg, err := resolveMainPackage(projectDir, name, config)
+		if err != nil {
+			return err
+		}
+		packages = []mainPackage{pkg}
+	}
+	primary, err := primaryMainDir(projectDir, config)
+	if err != nil {
+		return err
+	}
 
-	// Parse flags for specific platforms
+	// Targets come from --target, the platform flags build for the host architecture
+	platforms := []string{}
 	if allFlag, _ := cmd.Flags().GetBool("all"); allFlag {
 		platforms = append(platforms, "linux", "darwin", "windows")
 	}
-
 	if linuxFlag, _ := cmd.Flags().GetBool("linux"); linuxFlag {
 		platforms = append(platforms, "linux")
 	}
-
 	if macFlag, _ := cmd.Flags().GetBool("mac"); macFlag {
 		platforms = append(platforms, "darwin")
 	}
-
 	if windowsFlag, _ := cmd.Flags().GetBool("windows"); windowsFlag {
 		platforms = append(platforms, "windows")
 	}
+	values := append([]string{}, buildTargetList...)
+	for _, platform := range platforms {
+		values = append(values, platform+"/"+runtime.GOARCH)
+	}
 
-	// If no flags are provided, default to the current platform
-	if len(platforms) == 0 {
-		platforms = append(platforms, runtime.GOOS)
+	// If no target is given, default to the current platform
+	if len(values) == 0 {
+		values = append(values, runtime.GOOS+"/"+runtime.GOARCH)
+	}
+	targets, err := parseBuildTargets(values)
+	if err != nil {
+		return err
+	}
+	if err := validateBuildTargets(targets); err != nil {
+		return err
 	}
 
-	// Iterate over the platforms and build for each one
-	for _, platform := range platforms {
-		var platformOutputName string
-
-		// Detect the platform and architecture
-		switch platform {
-		case "linux":
-			// Linux
-			switch runtime.GOARCH {
-			case "amd64":
-				platformOutputName = "goi-linux-amd64"
-			case "arm64":
-				platformOutputName = "goi-linux-arm64"
-			default:
-				return fmt.Errorf("unsupported architecture %s for Linux", runtime.GOARCH)
-			}
-		case "darwin":
-			// macOS (Apple Silicon or Intel)
-			switch runtime.GOARCH {
-			case "amd64":
-				platformOutputName = "goi-macos-amd64"
-			case "arm64":
-				platformOutputName = "goi-macos-arm64"
-			default:
-				return fmt.Errorf("unsupported architecture %s for macOS", runtime.GOARCH)
-			}
-		case "windows":
-			// Windows
-			switch runtime.GOARCH {
-			case "amd64":
-				platformOutputName = "goi-win-amd64.exe"
-			case "arm64":
-				platformOutputName = "goi-win-arm64.exe"
-			default:
-				return fmt.Errorf("unsupported architecture %s for Windows", runtime.GOARCH)
-			}
-		default:
-			return fmt.Errorf("unsupported operating system %s", platform)
+	// Build every package for every target
+	var jobs []*buildJob
+	for _, pkg := range packages {
+		// The project's main package keeps the configured binary name, the others are named after their directory
+		binary := pkg.Name
+		if pkg.Dir == primary {
+			binary = config.Build.Binary
+		}
+		for _, target := range targets {
+			jobs = append(jobs, &buildJob{
+				pkg:    pkg,
+				target: target,
+				output: filepath.Join(config.Build.Output, artifactName(binary, target)),
+			})
 		}
+	}
 
-		// Construct the build command for the current platform
-		cmdArgs := append([]string{"build"}, buildFlags...)
-		cmdArgs = append(cmdArgs, "-o", "build/"+platformOutputName, ".")
+	utils.PrintInfo(fmt.Sprintf("Building %d artifact(s) with %d worker(s)", len(jobs), max(1, min(buildParallel, len(jobs)))))
+	runBuildJobs(jobs, buildFlags, buildParallel)
+	printBuildSummary(jobs)
 
-		// Run the build command
-		buildCommand := exec.Command("go", cmdArgs...)
-		buildCommand.Stdout = os.Stdout
-		buildCommand.Stderr = os.Stderr
-		if err := buildCommand.Run(); err != nil {
-			return fmt.Errorf("failed to run 'go build' for platform %s: %w", platform, err)
+	failed := 0
+	for _, job := range jobs {
+		if job.err != nil {
+			failed++
 		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d builds failed", failed, len(jobs))
+	}
+	return nil
+}
 
-		// Output success message using the utils
-		utils.PrintSuccess(fmt.Sprintf("Successfully built Go project for %s and saved to build/%s", platform, platformOutputName))
+// buildPackage returns the package to compile for a main file or package directory
+func buildPackage(main string) string {
+	if info, err := os.Stat(main); err == nil && info.IsDir() {
+		return goRunTarget(main)
+	}
+	dir := filepath.Dir(main)
+	if dir == "." {
+		return "."
 	}
+	return goRunTarget(dir)
+}
 
-	return nil
+// primaryMainDir returns the directory of the project's main package: the main setting of goi.yaml, the module
+// root when it is a main package, or the only main package of the module. It is empty when none applies.
+func primaryMainDir(root string, config *projectConfig) (string, error) {
+	if config.Main != "" {
+		return mainPackageDir(config.Main), nil
+	}
+	packages, err := discoverMainPackages(root, config)
+	if err != nil {
+		return "", err
+	}
+	for _, pkg := range packages {
+		if pkg.Dir == "." {
+			return ".", nil
+		}
+	}
+	if len(packages) == 1 {
+		return packages[0].Dir, nil
+	}
+	return "", nil
 }
 
 // Initialize flags for the BuildCmd
 func init() {
 	// Add flags for specific platform builds
-	BuildCmd.Flags().BoolP("all", "a", false, "Build for all platforms (linux, darwin, windows)")
-	BuildCmd.Flags().BoolP("linux", "l", false, "Build for Linux")
-	BuildCmd.Flags().BoolP("mac", "m", false, "Build for macOS")
-	BuildCmd.Flags().BoolP("windows", "w", false, "Build for Windows")
+	BuildCmd.Flags().StringSliceVarP(&buildTargetList, "target", "t", nil, "Targets to build as os/arch, e.g. linux/amd64,darwin/arm64 (repeatable)")
+	BuildCmd.Flags().BoolP("all", "a", false, "Build for all platforms (linux, darwin, windows) on the host architecture")
+	BuildCmd.Flags().BoolP("linux", "l", false, "Build for Linux on the host architecture")
+	BuildCmd.Flags().BoolP("mac", "m", false, "Build for macOS on the host architecture")
+	BuildCmd.Flags().BoolP("windows", "w", false, "Build for Windows on the host architecture")
+	BuildCmd.Flags().StringVar(&buildVersion, "version", "", "Version stamped into the binary (defaults to the latest git tag)")
+	BuildCmd.Flags().IntVarP(&buildParallel, "parallel", "j", runtime.NumCPU(), "Number of targets built at the same time")
+
+	// Select the main packages
+	BuildCmd.Flags().BoolVar(&buildAllCmds, "all-cmds", false, "Build every main package of the module")
 }
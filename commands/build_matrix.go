@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"fmt"
+	"goi/utils"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Flag variables for the target matrix of 'goi build'
+var buildTargetList []string
+var buildParallel int
+
+// buildTarget is a GOOS/GOARCH pair to compile for
+type buildTarget struct {
+	OS   string
+	Arch string
+}
+
+func (t buildTarget) String() string {
+	return t.OS + "/" + t.Arch
+}
+
+// buildJob compiles one main package for one target
+type buildJob struct {
+	pkg      mainPackage
+	target   buildTarget
+	output   string
+	size     int64
+	duration time.Duration
+	log      string // Compiler output
+	err      error
+}
+
+// parseBuildTargets parses os/arch pairs such as linux/amd64, dropping duplicates
+func parseBuildTargets(values []string) ([]buildTarget, error) {
+	var targets []buildTarget
+	seen := map[buildTarget]bool{}
+	for _, value := range values {
+		goos, goarch, ok := strings.Cut(strings.TrimSpace(value), "/")
+		if !ok || goos == "" || goarch == "" || strings.Contains(goarch, "/") {
+			return nil, fmt.Errorf("invalid target %q, expected os/arch such as linux/amd64", value)
+		}
+		target := buildTarget{OS: goos, Arch: goarch}
+		if !seen[target] {
+			seen[target] = true
+			targets = append(targets, target)
+		}
+	}
+	return targets, nil
+}
+
+// validateBuildTargets checks the targets against 'go tool dist list'. When the list cannot be read the
+// targets are left for 'go build' to reject.
+func validateBuildTargets(targets []buildTarget) error {
+	supported, err := supportedBuildTargets()
+	if err != nil {
+		return nil
+	}
+	for _, target := range targets {
+		if !supported[target] {
+			return fmt.Errorf("unsupported target %s, see 'go tool dist list'", target)
+		}
+	}
+	return nil
+}
+
+// supportedBuildTargets returns the targets of 'go tool dist list'
+func supportedBuildTargets() (map[buildTarget]bool, error) {
+	output, err := exec.Command("go", "tool", "dist", "list").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the supported targets: %w", err)
+	}
+	supported := map[buildTarget]bool{}
+	for _, line := range strings.Fields(string(output)) {
+		if goos, goarch, ok := strings.Cut(line, "/"); ok {
+			supported[buildTarget{OS: goos, Arch: goarch}] = true
+		}
+	}
+	return supported, nil
+}
+
+// artifactName names a binary <binary>-<os>-<arch>, with .exe for Windows
+func artifactName(binary string, target buildTarget) string {
+	name := fmt.Sprintf("%s-%s-%s", binary, target.OS, target.Arch)
+	if target.OS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// runBuildJobs compiles the jobs with at most workers builds at a time, reporting each one as it finishes
+func runBuildJobs(jobs []*buildJob, flags []string, workers int) {
+	workers = max(1, min(workers, len(jobs)))
+	queue := make(chan *buildJob)
+	var outputMu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				job.run(flags)
+				outputMu.Lock()
+				if job.err != nil {
+					utils.PrintWarning(fmt.Sprintf("Failed to build %s for %s: %v", job.pkg.target(), job.target, job.err))
+				} else {
+					utils.PrintSuccess(fmt.Sprintf("Built %s for %s in %s", job.pkg.target(), job.target, job.duration.Round(time.Millisecond)))
+				}
+				outputMu.Unlock()
+			}
+		}()
+	}
+	for _, job := range jobs {
+		queue <- job
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// run compiles the job with GOOS and GOARCH set to its target
+func (j *buildJob) run(flags []string) {
+	started := time.Now()
+	args := append([]string{"build"}, flags...)
+	args = append(args, "-o", j.output, j.pkg.target())
+
+	build := exec.Command("go", args...)
+	build.Env = append(os.Environ(), "GOOS="+j.target.OS, "GOARCH="+j.target.Arch)
+	output, err := build.CombinedOutput()
+	j.duration = time.Since(started)
+	j.log = strings.TrimSpace(string(output))
+	if err != nil {
+		j.err = fmt.Errorf("'go build' failed: %w", err)
+		return
+	}
+	if info, err := os.Stat(j.output); err == nil {
+		j.size = info.Size()
+	}
+}
+
+// printBuildSummary prints a table of the builds followed by the compiler output of the failed ones
+func printBuildSummary(jobs []*buildJob) {
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PACKAGE\tTARGET\tARTIFACT\tSIZE\tTIME\tSTATUS")
+	for _, job := range jobs {
+		size, status := "-", "ok"
+		if job.err != nil {
+			status = "failed"
+		} else {
+			size = formatSize(job.size)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", job.pkg.target(), job.target, filepath.ToSlash(job.output), size, job.duration.Round(time.Millisecond), status)
+	}
+	w.Flush()
+
+	for _, job := range jobs {
+		if job.err != nil && job.log != "" {
+			fmt.Printf("\n%s for %s:\n%s\n", job.pkg.target(), job.target, job.log)
+		}
+	}
+}
+
+// formatSize formats a byte count with a binary unit, e.g. 6.2 MiB
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultVersionPackage is the package that 'goi make version' generates and 'goi build' stamps
+const defaultVersionPackage = "internal/version"
+
+// buildVersion overrides the version taken from the latest git tag
+var buildVersion string
+
+// buildInfo is the build information stamped into the version package
+type buildInfo struct {
+	Version   string
+	Commit    string
+	BuildDate string
+	Dirty     bool
+}
+
+// MakeVersionCmd generates the package that receives the version stamped by 'goi build'
+var MakeVersionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Generate the version package stamped by 'goi build'",
+	Long: `The 'version' command generates internal/version/version.go (or the
+build.version_package directory of goi.yaml) with Version, Commit, BuildDate and
+Dirty variables. 'goi build' sets them through -ldflags -X, so the binary can
+report what it was built from:
+
+  fmt.Println(version.String()) // v1.4.0 (3f2a9c1, 2026-01-02T15:04:05Z)`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		moduleName, err := getModuleNameFromGoMod(".")
+		if err != nil {
+			return fmt.Errorf("failed to get module name from go.mod: %w", err)
+		}
+		config, err := loadProjectConfig()
+		if err != nil {
+			return err
+		}
+
+		file, err := renderVersionFile(strings.TrimSpace(moduleName), config.Build.VersionPackage)
+		if err != nil {
+			return err
+		}
+		return writeGeneratedFiles([]generatedFile{file})
+	},
+}
+
+// renderVersionFile renders the version package without touching the disk
+func renderVersionFile(moduleName, dir string) (generatedFile, error) {
+	tmpl, err := parseTemplateForResource("version")
+	if err != nil {
+		return generatedFile{}, err
+	}
+
+	pkg := filepath.Base(filepath.Clean(dir))
+	data := map[string]interface{}{
+		"Package":    pkg,
+		"ImportPath": versionImportPath(moduleName, dir),
+		"ModuleName": moduleName,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return generatedFile{}, fmt.Errorf("failed to render version template: %w", err)
+	}
+
+	path := filepath.Join(dir, "version.go")
+	content, err := formatGoSource(path, buf.Bytes())
+	if err != nil {
+		return generatedFile{}, err
+	}
+	return generatedFile{
+		Path:    path,
+		Content: content,
+		Label:   fmt.Sprintf("Version package '%s'", pkg),
+	}, nil
+}
+
+// versionImportPath returns the import path of the version package in the module
+func versionImportPath(moduleName, dir string) string {
+	return path.Join(moduleName, filepath.ToSlash(filepath.Clean(dir)))
+}
+
+// versionLDFlags returns the -X flags stamping the build information into the version package,
+// or nothing when the project has no version package (see 'goi make version')
+func versionLDFlags(config *projectConfig, info buildInfo) (string, error) {
+	dir := config.Build.VersionPackage
+	if dir == "" || !fileExists(dir) {
+		return "", nil
+	}
+	moduleName, err := getModuleNameFromGoMod(".")
+	if err != nil {
+		return "", fmt.Errorf("failed to get module name from go.mod: %w", err)
+	}
+
+	importPath := versionImportPath(strings.TrimSpace(moduleName), dir)
+	values := []struct{ name, value string }{
+		{"Version", info.Version},
+		{"Commit", info.Commit},
+		{"BuildDate", info.BuildDate},
+		{"Dirty", strconv.FormatBool(info.Dirty)},
+	}
+	var flags []string
+	for _, v := range values {
+		flags = append(flags, quoteLDFlag(fmt.Sprintf("-X=%s.%s=%s", importPath, v.name, v.value)))
+	}
+	return strings.Join(flags, " "), nil
+}
+
+// quoteLDFlag quotes an argument with spaces so it survives the splitting of -ldflags, which strips
+// single or double quotes around a field but does not unescape anything inside them
+func quoteLDFlag(arg string) string {
+	if !strings.ContainsAny(arg, " \t'\"") {
+		return arg
+	}
+	if !strings.Contains(arg, "'") {
+		return "'" + arg + "'"
+	}
+	return `"` + arg + `"`
+}
+
+// currentBuildInfo reads the build information from git. Outside a repository the version is "dev"
+// and the commit "none". SOURCE_DATE_EPOCH replaces the build date for reproducible builds.
+func currentBuildInfo(version string) (buildInfo, error) {
+	info := buildInfo{Version: version, Commit: "none"}
+
+	if info.Version == "" {
+		info.Version = "dev"
+		if tag, err := gitOutput("describe", "--tags", "--abbrev=0"); err == nil && tag != "" {
+			info.Version = tag
+		}
+	}
+	if commit, err := gitOutput("rev-parse", "--short", "HEAD"); err == nil && commit != "" {
+		info.Commit = commit
+	}
+	// Untracked files such as the build directory do not make a build dirty, only changes to tracked files
+	if status, err := gitOutput("status", "--porcelain", "--untracked-files=no"); err == nil {
+		info.Dirty = status != ""
+	}
+
+	date := time.Now()
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		seconds, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return buildInfo{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: %w", epoch, err)
+		}
+		date = time.Unix(seconds, 0)
+	}
+	info.BuildDate = date.UTC().Format(time.RFC3339)
+	return info, nil
+}
+
+// gitOutput runs git in the current directory and returns its trimmed output
+func gitOutput(args ...string) (string, error) {
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
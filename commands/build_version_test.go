@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestCurrentBuildInfoIgnoresUntrackedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	writeFile := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q")
+	writeFile("main.go", "package main\n")
+	git("add", "main.go")
+	git("-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial")
+
+	// Build output is untracked and must not mark the build dirty
+	writeFile(filepath.Join("build", "app"), "binary")
+	info, err := currentBuildInfo("v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Dirty {
+		t.Errorf("build with only untracked files is dirty, want clean")
+	}
+	if info.Commit == "none" {
+		t.Errorf("commit was not detected")
+	}
+
+	writeFile("main.go", "package main\n\nfunc main() {}\n")
+	if info, err = currentBuildInfo("v1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if !info.Dirty {
+		t.Errorf("build with a modified tracked file is clean, want dirty")
+	}
+}
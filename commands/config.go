@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"fmt"
+	"goi/utils"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// configValidators check the settings whose values are restricted before they are written to goi.yaml
+var configValidators = map[string]func(value string) error{
+	"http": func(value string) error {
+		if _, ok := httpFrameworks[value]; ok {
+			return nil
+		}
+		if _, ok := httpFrameworkAliases[value]; ok {
+			return nil
+		}
+		return fmt.Errorf("unknown HTTP framework %q, expected one of: %s", value, strings.Join(httpFrameworkNames(), ", "))
+	},
+	"orm": func(value string) error {
+		if _, ok := ormBackends[value]; ok {
+			return nil
+		}
+		if _, ok := ormAliases[value]; ok {
+			return nil
+		}
+		return fmt.Errorf("unknown ORM %q, expected one of: %s", value, strings.Join(ormNames(), ", "))
+	},
+	"serve.restart": func(value string) error {
+		if !containsString(serveRestartPolicies, value) {
+			return fmt.Errorf("unknown restart policy %q, expected one of: %s", value, strings.Join(serveRestartPolicies, ", "))
+		}
+		return nil
+	},
+	"database.driver": func(value string) error {
+		if _, ok := dialectAliases[strings.ToLower(value)]; !ok {
+			return fmt.Errorf("unknown database driver %q, expected mysql or postgres", value)
+		}
+		return nil
+	},
+	"deploy.target": func(value string) error {
+		if value != "docker" && value != "heroku" {
+			return fmt.Errorf("unknown deploy target %q, expected docker or heroku", value)
+		}
+		return nil
+	},
+}
+
+// ConfigCmd groups the commands that read and edit goi.yaml
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read and edit the project settings in goi.yaml",
+	Long: `The 'config' command reads and edits goi.yaml, the project settings shared by
+every goi command: the main package, the build output, the generator layout, the
+HTTP framework and ORM, the database connection and the deploy target.
+
+Settings are addressed by dotted keys such as build.binary or layout.handlers.
+Command-line flags always take precedence over the file.`,
+	Example: `  goi config list
+  goi config get build.output
+  goi config set http chi
+  goi config set layout.handlers internal/handlers`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("subcommand is required. Example: goi config list")
+	},
+}
+
+// ConfigGetCmd prints the effective value of a setting
+var ConfigGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the value of a setting (the default when goi.yaml does not set it)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := loadProjectConfig()
+		if err != nil {
+			return err
+		}
+		field, err := lookupConfigField(config, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println(field.Value.String())
+		return nil
+	},
+}
+
+// ConfigSetCmd writes a setting to goi.yaml
+var ConfigSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Write a setting to goi.yaml, creating the file if needed",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, value := args[0], args[1]
+		if validate, ok := configValidators[key]; ok {
+			if err := validate(value); err != nil {
+				return err
+			}
+		}
+		if err := setProjectConfigValue(key, value); err != nil {
+			return err
+		}
+		utils.PrintSuccess(fmt.Sprintf("Set %s = %s in %s", key, value, projectConfigFile))
+		return nil
+	},
+}
+
+// ConfigListCmd prints every setting with its effective value
+var ConfigListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List every setting with its effective value",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := loadProjectConfig()
+		if err != nil {
+			return err
+		}
+		for _, field := range configFields(config) {
+			fmt.Printf("%s = %s\n", field.Key, field.Value.String())
+		}
+		return nil
+	},
+}
+
+func init() {
+	ConfigCmd.AddCommand(ConfigGetCmd)
+	ConfigCmd.AddCommand(ConfigSetCmd)
+	ConfigCmd.AddCommand(ConfigListCmd)
+}
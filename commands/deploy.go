@@ -18,18 +18,31 @@ var DeployCmd = &cobra.Command{
 
 // runDeployCommand handles the deployment logic
 func runDeployCommand(cmd *cobra.Command, args []string) error {
-	// Get deployment target (Docker, Heroku, etc.)
+	// Make the project's .env available to docker and heroku
+	if err := loadEnvFile(defaultEnvFile); err != nil {
+		return err
+	}
+
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	// Get deployment target (Docker, Heroku, etc.), defaulting to deploy.target of goi.yaml
 	target, _ := cmd.Flags().GetString("target")
+	if !cmd.Flags().Changed("target") {
+		target = config.Deploy.Target
+	}
 
 	switch target {
 	case "docker":
 		// Deploy using Docker
-		if err := deployWithDocker(); err != nil {
+		if err := deployWithDocker(config.Deploy.Docker); err != nil {
 			return fmt.Errorf("docker deployment failed: %w", err)
 		}
 	case "heroku":
 		// Deploy to Heroku
-		if err := deployWithHeroku(); err != nil {
+		if err := deployWithHeroku(config.Deploy.Heroku); err != nil {
 			return fmt.Errorf("heroku deployment failed: %w", err)
 		}
 	default:
@@ -41,14 +54,14 @@ func runDeployCommand(cmd *cobra.Command, args []string) error {
 }
 
 // deployWithDocker builds a Docker image and pushes it to the Docker registry
-func deployWithDocker() error {
+func deployWithDocker(docker dockerConfig) error {
 	// Ensure there's a Dockerfile in the project
 	if _, err := os.Stat("Dockerfile"); os.IsNotExist(err) {
 		return fmt.Errorf("dockerfile not found in the current directory")
 	}
 
 	// Build Docker image
-	cmd := exec.Command("docker", "build", "-t", "goi-project", ".")
+	cmd := exec.Command("docker", "build", "-t", docker.Image, ".")
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
@@ -57,7 +70,7 @@ func deployWithDocker() error {
 
 	// Optionally, push the image to a Docker registry (e.g., Docker Hub)
 	// You can add this section if you want to push the image to a registry
-	// cmdPush := exec.Command("docker", "push", "goi-project")
+	// cmdPush := exec.Command("docker", "push", docker.Image)
 	// cmdPush.Stdout = os.Stdout
 	// cmdPush.Stderr = os.Stderr
 	// if err := cmdPush.Run(); err != nil {
@@ -68,7 +81,7 @@ func deployWithDocker() error {
 }
 
 // deployWithHeroku deploys the project to Heroku
-func deployWithHeroku() error {
+func deployWithHeroku(heroku herokuConfig) error {
 	// Ensure the Heroku CLI is installed and the user is logged in
 	if err := checkHerokuCLI(); err != nil {
 		return err
@@ -83,7 +96,7 @@ func deployWithHeroku() error {
 	}
 
 	// Deploy to Heroku
-	cmdDeploy := exec.Command("git", "push", "heroku", "master")
+	cmdDeploy := exec.Command("git", "push", heroku.Remote, heroku.Branch)
 	cmdDeploy.Stdout = os.Stdout
 	cmdDeploy.Stderr = os.Stderr
 	if err := cmdDeploy.Run(); err != nil {
@@ -105,5 +118,5 @@ func checkHerokuCLI() error {
 // Initialize flags for the DeployCmd
 func init() {
 	// Add flags to specify the target for deployment (docker or heroku)
-	DeployCmd.Flags().StringP("target", "t", "docker", "specify deployment target: 'docker' or 'heroku'")
+	DeployCmd.Flags().StringP("target", "t", "docker", "specify deployment target: 'docker' or 'heroku' (defaults to deploy.target of goi.yaml, then docker)")
 }
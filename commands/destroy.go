@@ -0,0 +1,364 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"goi/utils"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Flag variables for the destroy command
+var destroyForce bool
+var destroyDryRun bool
+
+// DestroyCmd groups the commands that remove generated scaffolds
+var DestroyCmd = &cobra.Command{
+	Use:   "destroy",
+	Short: "Remove scaffolds generated by goi make, goi destroy <type> <name>",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("subcommand is required. Example: goi destroy resource <name>")
+	},
+}
+
+// DestroyResourceCmd removes the files generated for a resource and the code injected for it
+var DestroyResourceCmd = &cobra.Command{
+	Use:   "resource <name>",
+	Short: "Remove the files, routes and wiring generated for a resource",
+	Long: `The 'destroy resource' command removes exactly what 'goi make' generated for a
+resource, as recorded in .goi/manifest.json: the generated files, the routes
+registered in the router setup file and the dependencies wired into the container.
+
+Files modified since they were generated are never deleted; the command refuses
+to run until they are restored, or --force is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return destroyResource(args[0])
+	},
+}
+
+func init() {
+	DestroyCmd.AddCommand(DestroyResourceCmd)
+
+	DestroyCmd.PersistentFlags().BoolVarP(&destroyForce, "force", "f", false, "Delete generated files even if they were modified")
+	DestroyCmd.PersistentFlags().BoolVar(&destroyDryRun, "dry-run", false, "Print what would be removed without changing anything")
+}
+
+// destroyResource removes the generated files of a resource and un-injects its routes and wiring
+func destroyResource(name string) error {
+	manifest, err := loadManifest()
+	if err != nil {
+		return err
+	}
+
+	key := manifestKey(name)
+	entry, ok := manifest.Resources[key]
+	if !ok {
+		return fmt.Errorf("no record of '%s' in %s, only resources generated by goi make can be destroyed", key, manifestPath)
+	}
+
+	// Check every generated file before touching anything
+	var paths, deletions, modified []string
+	for path := range entry.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		content, err := os.ReadFile(filepath.FromSlash(path))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if contentHash(content) != entry.Files[path] {
+			modified = append(modified, path)
+		}
+		deletions = append(deletions, path)
+	}
+	if len(modified) > 0 && !destroyForce {
+		return fmt.Errorf("refusing to destroy '%s', these files were modified after generation (use --force to delete them anyway):\n  %s", key, strings.Join(modified, "\n  "))
+	}
+
+	// Remove the routes and wiring injected into project files
+	var edits []generatedFile
+	for _, path := range entry.Edits {
+		src, err := os.ReadFile(filepath.FromSlash(path))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		updated, err := removeInjectedCode(path, src, key)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(updated, src) {
+			edits = append(edits, generatedFile{Path: filepath.FromSlash(path), Content: updated})
+		}
+	}
+
+	if destroyDryRun {
+		for _, path := range deletions {
+			fmt.Printf("%-10s %s\n", "delete", path)
+		}
+		for _, edit := range edits {
+			fmt.Printf("%-10s %s\n", "update", filepath.ToSlash(edit.Path))
+		}
+		return nil
+	}
+
+	for _, edit := range edits {
+		if err := os.WriteFile(edit.Path, edit.Content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", edit.Path, err)
+		}
+		utils.PrintSuccess(fmt.Sprintf("Removed '%s' from %s", key, filepath.ToSlash(edit.Path)))
+	}
+	for _, path := range deletions {
+		if err := os.Remove(filepath.FromSlash(path)); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", path, err)
+		}
+		utils.PrintSuccess(fmt.Sprintf("Deleted %s", path))
+		removeEmptyDir(filepath.Dir(filepath.FromSlash(path)))
+	}
+
+	delete(manifest.Resources, key)
+	if err := manifest.save(); err != nil {
+		return err
+	}
+	utils.PrintSuccess(fmt.Sprintf("Resource '%s' destroyed", key))
+	return nil
+}
+
+// removeEmptyDir deletes a generated package directory once its last file is gone
+func removeEmptyDir(dir string) {
+	if dir == "." || dir == "" {
+		return
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) == 0 {
+		os.Remove(dir)
+	}
+}
+
+// removeInjectedCode removes the route statements, container fields and assignments, and provider set
+// entries that 'goi make resource' injected for the resource, then drops imports left unused
+func removeInjectedCode(filePath string, src []byte, pascal string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filePath, err)
+	}
+
+	injected := map[string]bool{
+		pascal + "Repository": true,
+		pascal + "Service":    true,
+		pascal + "Handler":    true,
+	}
+	constructors := map[string]bool{
+		"repository.New" + pascal + "Repository": true,
+		"services.New" + pascal + "Service":      true,
+		"handlers.New" + pascal + "Handler":      true,
+	}
+	routeVars := map[string]bool{
+		utils.VarName(pascal) + "Handler": true,
+		utils.VarName(pascal) + "Routes":  true,
+	}
+
+	var ranges [][2]int
+	removeLines := func(from, to token.Pos) {
+		ranges = append(ranges, lineRange(src, fset.Position(from).Offset, fset.Position(to).Offset))
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Body == nil {
+				continue
+			}
+			for _, stmt := range d.Body.List {
+				if mentionsIdent(stmt, routeVars) || assignsField(stmt, injected) {
+					removeLines(stmt.Pos(), stmt.End())
+				}
+			}
+		case *ast.GenDecl:
+			if d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
+				st, ok := spec.(*ast.TypeSpec).Type.(*ast.StructType)
+				if !ok || spec.(*ast.TypeSpec).Name.Name != "Container" {
+					continue
+				}
+				for _, field := range st.Fields.List {
+					if len(field.Names) == 1 && injected[field.Names[0].Name] {
+						removeLines(field.Pos(), field.End())
+					}
+				}
+			}
+		}
+	}
+
+	// The "// <Name> routes" comment that earlier versions wrote above the route statements
+	for _, group := range file.Comments {
+		if strings.TrimSpace(group.Text()) != pascal+" routes" {
+			continue
+		}
+		r := lineRange(src, fset.Position(group.Pos()).Offset, fset.Position(group.End()).Offset)
+		// Also take the blank line that separated the routes from the statements before them
+		if r[0] > 0 {
+			prev := bytes.LastIndexByte(src[:r[0]-1], '\n') + 1
+			if len(bytes.TrimSpace(src[prev:r[0]])) == 0 {
+				r[0] = prev
+			}
+		}
+		ranges = append(ranges, r)
+	}
+
+	// Provider set entries, one per argument
+	if call := findProviderCall(file); call != nil {
+		for i, arg := range call.Args {
+			if !constructors[exprString(arg)] {
+				continue
+			}
+			start := fset.Position(arg.Pos()).Offset
+			end := fset.Position(arg.End()).Offset
+			if i+1 < len(call.Args) {
+				end = fset.Position(call.Args[i+1].Pos()).Offset
+			} else if comma := bytes.IndexByte(src[end:fset.Position(call.Rparen).Offset], ','); comma >= 0 {
+				end += comma + 1
+			}
+			ranges = append(ranges, [2]int{start, end})
+		}
+	}
+
+	if len(ranges) == 0 {
+		return src, nil
+	}
+	updated := removeRanges(src, ranges)
+	updated = removeUnusedImports(filePath, updated, "handlers", "repository", "services")
+	return formatGeneratedSource(filePath, updated)
+}
+
+// mentionsIdent reports whether the statement uses any of the given identifiers
+func mentionsIdent(stmt ast.Stmt, names map[string]bool) bool {
+	found := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && names[ident.Name] {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// assignsField reports whether the statement assigns one of the given fields, e.g. c.ProductHandler = ...
+func assignsField(stmt ast.Stmt, fields map[string]bool) bool {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok {
+		return false
+	}
+	for _, lhs := range assign.Lhs {
+		if sel, ok := lhs.(*ast.SelectorExpr); ok && fields[sel.Sel.Name] {
+			return true
+		}
+	}
+	return false
+}
+
+// lineRange widens a byte range to the full lines it covers, including the trailing newline
+func lineRange(src []byte, start, end int) [2]int {
+	start = bytes.LastIndexByte(src[:start], '\n') + 1
+	if nl := bytes.IndexByte(src[end:], '\n'); nl >= 0 {
+		end += nl + 1
+	} else {
+		end = len(src)
+	}
+	return [2]int{start, end}
+}
+
+// removeRanges deletes the given byte ranges from src; overlapping ranges are merged
+func removeRanges(src []byte, ranges [][2]int) []byte {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+
+	var out []byte
+	last := 0
+	for _, r := range ranges {
+		if r[0] > last {
+			out = append(out, src[last:r[0]]...)
+		}
+		if r[1] > last {
+			last = r[1]
+		}
+	}
+	return append(out, src[last:]...)
+}
+
+// removeUnusedImports drops the imports of the named packages once the file no longer references them
+func removeUnusedImports(filePath string, src []byte, packages ...string) []byte {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, src, parser.ParseComments)
+	if err != nil {
+		return src
+	}
+
+	used := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	var ranges [][2]int
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			imp := spec.(*ast.ImportSpec)
+			importPath, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				continue
+			}
+			name := path.Base(importPath)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			if used[name] || !containsString(packages, name) {
+				continue
+			}
+			if !gen.Lparen.IsValid() {
+				ranges = append(ranges, lineRange(src, fset.Position(gen.Pos()).Offset, fset.Position(gen.End()).Offset))
+			} else {
+				ranges = append(ranges, lineRange(src, fset.Position(imp.Pos()).Offset, fset.Position(imp.End()).Offset))
+			}
+		}
+	}
+	if len(ranges) == 0 {
+		return src
+	}
+	return removeRanges(src, ranges)
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
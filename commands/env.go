@@ -0,0 +1,281 @@
+package commands
+
+import (
+	"fmt"
+	"goi/utils"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultEnvFile is the environment file loaded by serve, backup, restore, migrate and deploy
+const defaultEnvFile = ".env"
+
+// envExampleFile documents the variables of .env and is committed in its place
+const envExampleFile = ".env.example"
+
+// defaultEnvSchema lists the variables of a goi project when goi.yaml has no env section
+var defaultEnvSchema = []envVar{
+	{Key: "APP_ENV", Default: "development", Description: "Environment the application runs in"},
+	{Key: "APP_PORT", Default: "8080", Description: "Port the HTTP server listens on"},
+	{Key: "DB_HOST", Default: "127.0.0.1", Required: true, Description: "Database host"},
+	{Key: "DB_PORT", Default: "3306", Required: true, Description: "Database port"},
+	{Key: "DB_USER", Required: true, Description: "Database user"},
+	{Key: "DB_PASSWORD", Description: "Database password"},
+	{Key: "DB_NAME", Required: true, Description: "Database name"},
+}
+
+// Flag variables for the env commands
+var envFile string
+var envForce bool
+
+// EnvCmd groups the commands that manage the project's .env file
+var EnvCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Create, check and edit the project's .env file",
+	Long: `The 'env' command manages the .env file that serve, backup, restore, migrate
+and deploy load automatically. Variables already set in the environment take
+precedence over the file.
+
+The expected variables come from the env section of goi.yaml:
+
+  env:
+    - key: DB_USER
+      required: true
+      description: Database user
+    - key: APP_PORT
+      default: "8080"
+
+Without one, the APP_* and DB_* variables of a goi project are expected.`,
+	Example: `  goi env init
+  goi env set DB_HOST localhost
+  goi env check
+  goi env diff`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("subcommand is required. Example: goi env check")
+	},
+}
+
+// EnvInitCmd writes .env and .env.example from the schema
+var EnvInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write .env and .env.example with every expected variable",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schema, err := envSchema()
+		if err != nil {
+			return err
+		}
+
+		var example, env strings.Builder
+		for _, v := range schema {
+			comment := v.Description
+			if v.Required {
+				comment = strings.TrimSpace(comment + " (required)")
+			}
+			if comment != "" {
+				fmt.Fprintf(&example, "# %s\n", comment)
+			}
+			fmt.Fprintf(&example, "%s=%s\n", v.Key, utils.FormatEnvValue(v.Default))
+			fmt.Fprintf(&env, "%s=%s\n", v.Key, utils.FormatEnvValue(v.Default))
+		}
+
+		// .env holds secrets, so only the owner may read it
+		files := []struct {
+			path    string
+			content string
+			mode    os.FileMode
+		}{
+			{envExampleFile, example.String(), 0644},
+			{envFile, env.String(), 0600},
+		}
+		for _, file := range files {
+			if fileExists(file.path) && !envForce {
+				utils.PrintWarning(fmt.Sprintf("%s already exists, skipping it (use --force to overwrite)", file.path))
+				continue
+			}
+			if err := os.WriteFile(file.path, []byte(file.content), file.mode); err != nil {
+				return fmt.Errorf("failed to write %s: %w", file.path, err)
+			}
+			utils.PrintSuccess(fmt.Sprintf("%s created successfully", file.path))
+		}
+		return nil
+	},
+}
+
+// EnvCheckCmd reports the required variables that are missing or empty, and the optional ones that are missing
+var EnvCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Report required variables that are missing or empty, and optional ones that are missing",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schema, err := envSchema()
+		if err != nil {
+			return err
+		}
+		values, err := utils.ReadEnvFile(envFile)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", envFile, err)
+		}
+
+		problems := 0
+		for _, v := range schema {
+			value, ok := os.LookupEnv(v.Key)
+			if !ok {
+				value, ok = values[v.Key]
+			}
+			// Only required variables fail the check; optional ones are reported for information
+			switch {
+			case v.Required && !ok:
+				utils.PrintWarning(fmt.Sprintf("%s is required but missing", v.Key))
+				problems++
+			case v.Required && value == "":
+				utils.PrintWarning(fmt.Sprintf("%s is required but empty", v.Key))
+				problems++
+			case !ok:
+				utils.PrintInfo(fmt.Sprintf("%s is not set (optional)", v.Key))
+			}
+		}
+
+		if problems > 0 {
+			return fmt.Errorf("%d required variable(s) need a value in %s or the environment", problems, envFile)
+		}
+		utils.PrintSuccess("All required variables are set")
+		return nil
+	},
+}
+
+// EnvDiffCmd compares the variables of .env and .env.example
+var EnvDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the variables of .env and .env.example (values are not shown)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		env, err := utils.ReadEnvFile(envFile)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", envFile, err)
+		}
+		example, err := utils.ReadEnvFile(envExampleFile)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", envExampleFile, err)
+		}
+
+		missing := missingEnvKeys(example, env)
+		extra := missingEnvKeys(env, example)
+		for _, key := range missing {
+			fmt.Printf("- %s (in %s only)\n", key, envExampleFile)
+		}
+		for _, key := range extra {
+			fmt.Printf("+ %s (in %s only)\n", key, envFile)
+		}
+
+		if len(missing)+len(extra) > 0 {
+			return fmt.Errorf("%s and %s differ: %d missing, %d extra", envFile, envExampleFile, len(missing), len(extra))
+		}
+		utils.PrintSuccess(fmt.Sprintf("%s and %s declare the same variables", envFile, envExampleFile))
+		return nil
+	},
+}
+
+// EnvGetCmd prints a variable of .env
+var EnvGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the value of a variable in .env",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		values, err := utils.ReadEnvFile(envFile)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", envFile, err)
+		}
+		value, ok := values[args[0]]
+		if !ok {
+			return fmt.Errorf("%s is not set in %s", args[0], envFile)
+		}
+		fmt.Println(value)
+		return nil
+	},
+}
+
+// EnvSetCmd sets a variable in .env
+var EnvSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a variable in .env, creating the file if needed",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+		if key == "" || strings.ContainsAny(key, "= \t#") {
+			return fmt.Errorf("invalid variable name %q", key)
+		}
+		if err := utils.SetEnvFileValue(envFile, key, args[1]); err != nil {
+			return err
+		}
+		utils.PrintSuccess(fmt.Sprintf("Set %s in %s", key, envFile))
+		return nil
+	},
+}
+
+// EnvUnsetCmd removes a variable from .env
+var EnvUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a variable from .env",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		removed, err := utils.UnsetEnvFileValue(envFile, args[0])
+		if err != nil {
+			return err
+		}
+		if !removed {
+			utils.PrintInfo(fmt.Sprintf("%s is not set in %s", args[0], envFile))
+			return nil
+		}
+		utils.PrintSuccess(fmt.Sprintf("Removed %s from %s", args[0], envFile))
+		return nil
+	},
+}
+
+func init() {
+	EnvCmd.AddCommand(EnvInitCmd)
+	EnvCmd.AddCommand(EnvCheckCmd)
+	EnvCmd.AddCommand(EnvDiffCmd)
+	EnvCmd.AddCommand(EnvGetCmd)
+	EnvCmd.AddCommand(EnvSetCmd)
+	EnvCmd.AddCommand(EnvUnsetCmd)
+
+	EnvCmd.PersistentFlags().StringVar(&envFile, "env-file", defaultEnvFile, "Environment file to read and edit")
+	EnvInitCmd.Flags().BoolVarP(&envForce, "force", "f", false, "Overwrite .env and .env.example if they exist")
+}
+
+// envSchema returns the variables the project expects, from goi.yaml or the defaults
+func envSchema() ([]envVar, error) {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+	if len(config.Env) > 0 {
+		return config.Env, nil
+	}
+	return defaultEnvSchema, nil
+}
+
+// missingEnvKeys returns the keys of from that are not in to, sorted
+func missingEnvKeys(from, to map[string]string) []string {
+	var keys []string
+	for key := range from {
+		if _, ok := to[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// loadEnvFile exports the variables of the project's .env into the environment of goi and the commands it runs.
+// A missing file is not an error.
+func loadEnvFile(path string) error {
+	if err := utils.LoadEnvFile(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	return nil
+}
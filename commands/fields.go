@@ -0,0 +1,325 @@
+package commands
+
+import (
+	"fmt"
+	"go/token"
+	"goi/utils"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Field describes a single resource field parsed from the --fields flag
+type Field struct {
+	FieldName  string // Go identifier, e.g. UnitPrice
+	ParamName  string // Go parameter name, e.g. unitPrice
+	ColumnName string // Database column / JSON key, e.g. unit_price
+	FieldType  string // Go type, e.g. float64
+	JSONName   string // Key of the field in request and response bodies, usually the column name
+	JsonTag    string // Value of the json struct tag
+	BindingTag string // Value of the binding struct tag (gin validator rules)
+	GormTag    string // Value of the gorm struct tag
+	SQLType    string // MySQL column type used by migrations, e.g. VARCHAR(255)
+	PGType     string // PostgreSQL column type used by migrations, e.g. DOUBLE PRECISION
+	Required   bool   // Whether the rules include "required", which makes the column NOT NULL
+	Unique     bool   // Whether the column carries a unique index
+	Index      bool   // Whether the column carries a non-unique index
+	Nullable   bool   // Whether the column accepts NULL, which makes FieldType a pointer
+	Sample     string // Go literal that satisfies the binding rules, used by the generated tests
+}
+
+// fieldTypes maps the accepted short type names to their Go types
+var fieldTypes = map[string]string{
+	"string":    "string",
+	"text":      "string",
+	"int":       "int",
+	"int32":     "int32",
+	"int64":     "int64",
+	"uint":      "uint",
+	"uint64":    "uint64",
+	"float":     "float64",
+	"float32":   "float32",
+	"float64":   "float64",
+	"bool":      "bool",
+	"time":      "time.Time",
+	"time.Time": "time.Time",
+}
+
+// sqlTypes maps the accepted short type names to the MySQL column types used in migrations
+var sqlTypes = map[string]string{
+	"string":    "VARCHAR(255)",
+	"text":      "TEXT",
+	"int":       "BIGINT",
+	"int32":     "INT",
+	"int64":     "BIGINT",
+	"uint":      "BIGINT UNSIGNED",
+	"uint64":    "BIGINT UNSIGNED",
+	"float":     "DOUBLE",
+	"float32":   "FLOAT",
+	"float64":   "DOUBLE",
+	"bool":      "TINYINT(1)",
+	"time":      "DATETIME(3)",
+	"time.Time": "DATETIME(3)",
+}
+
+// pgTypes maps the accepted short type names to the PostgreSQL column types used in migrations.
+// PostgreSQL has no unsigned integers, so uint and uint64 share BIGINT with int64.
+var pgTypes = map[string]string{
+	"string":    "VARCHAR(255)",
+	"text":      "TEXT",
+	"int":       "BIGINT",
+	"int32":     "INTEGER",
+	"int64":     "BIGINT",
+	"uint":      "BIGINT",
+	"uint64":    "BIGINT",
+	"float":     "DOUBLE PRECISION",
+	"float32":   "REAL",
+	"float64":   "DOUBLE PRECISION",
+	"bool":      "BOOLEAN",
+	"time":      "TIMESTAMPTZ",
+	"time.Time": "TIMESTAMPTZ",
+}
+
+// parseFields parses a field specification such as
+// "name:string:required,price:float64:gte=0,sku:string:unique".
+// Several rules for the same field are separated with '|', e.g. "name:string:required|min=3".
+func parseFields(spec string) ([]Field, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+
+	var fields []Field
+	seen := map[string]bool{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		segments := strings.SplitN(part, ":", 3)
+		if len(segments) < 2 {
+			return nil, fmt.Errorf("invalid field %q, expected <name>:<type>[:<rules>]", part)
+		}
+
+		name := strings.TrimSpace(segments[0])
+		if !token.IsIdentifier(name) || token.IsKeyword(name) {
+			return nil, fmt.Errorf("invalid field name %q, expected a Go identifier that is not a keyword, e.g. unit_price", name)
+		}
+		if gormModelColumns[utils.Snake(name)] {
+			return nil, fmt.Errorf("field %q clashes with the %s column every model already has (id, created_at, updated_at, deleted_at)", name, utils.Snake(name))
+		}
+
+		var rules []string
+		if len(segments) == 3 {
+			rules = strings.Split(segments[2], "|")
+		}
+		field, err := newField(name, strings.TrimSpace(segments[1]), rules)
+		if err != nil {
+			return nil, err
+		}
+		if seen[field.ColumnName] {
+			return nil, fmt.Errorf("duplicate field %q", segments[0])
+		}
+		seen[field.ColumnName] = true
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// newField builds a field from its name, short type name and rules. Rules are gin validator rules,
+// plus "unique" and "index" which become database indexes.
+func newField(name, typeName string, rules []string) (Field, error) {
+	if name == "" {
+		return Field{}, fmt.Errorf("invalid field %q, name is empty", name+":"+typeName)
+	}
+	goType, ok := fieldTypes[typeName]
+	if !ok {
+		return Field{}, fmt.Errorf("unsupported type %q for field %q", typeName, name)
+	}
+
+	// Names from schemas and tables may contain dashes or spaces, but must still make a Go identifier
+	if pascal := utils.Pascal(name); pascal == "" || !token.IsIdentifier(pascal) || !unicode.IsUpper([]rune(pascal)[0]) {
+		return Field{}, fmt.Errorf("invalid field name %q, it does not make an exported Go identifier", name)
+	}
+
+	field := Field{
+		FieldName:  utils.Pascal(name),
+		ParamName:  utils.VarName(name),
+		ColumnName: utils.Snake(name),
+		FieldType:  goType,
+		SQLType:    sqlTypes[typeName],
+		PGType:     pgTypes[typeName],
+	}
+
+	var bindingRules, gormRules []string
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		switch rule {
+		case "":
+		case "unique":
+			field.Unique = true
+			gormRules = append(gormRules, "uniqueIndex")
+		case "index":
+			field.Index = true
+			gormRules = append(gormRules, "index")
+		default:
+			if rule == "required" {
+				field.Required = true
+				// gin's required rejects 0 and false, so numbers and booleans only get a NOT NULL column
+				if goType != "string" && goType != "time.Time" {
+					continue
+				}
+			}
+			bindingRules = append(bindingRules, rule)
+		}
+	}
+
+	field.Sample = sampleValue(goType, bindingRules)
+	field.JSONName = field.ColumnName
+	field.JsonTag = field.JSONName
+	if !field.Required {
+		field.JsonTag += ",omitempty"
+	}
+	field.BindingTag = strings.Join(bindingRules, ",")
+	gormRules = append([]string{"column:" + field.ColumnName}, gormRules...)
+	field.GormTag = strings.Join(gormRules, ";")
+	return field, nil
+}
+
+// nullable turns the field into a pointer, so a NULL column reads as nil instead of failing to scan
+func (f *Field) nullable() {
+	if f.Nullable {
+		return
+	}
+	f.Nullable = true
+	if f.Sample != "" {
+		f.Sample = fmt.Sprintf("func() *%s { v := %s(%s); return &v }()", f.FieldType, f.FieldType, f.Sample)
+	}
+	f.FieldType = "*" + f.FieldType
+}
+
+// fieldsNeedTime reports whether any field requires the "time" import
+func fieldsNeedTime(fields []Field) bool {
+	for _, field := range fields {
+		if strings.TrimPrefix(field.FieldType, "*") == "time.Time" {
+			return true
+		}
+	}
+	return false
+}
+
+// sampleValue returns a Go literal of the given type that passes the common gin validator rules.
+// Generated tests use it to build valid request bodies and models.
+func sampleValue(goType string, rules []string) string {
+	params := map[string]string{}
+	for _, rule := range rules {
+		key, value, _ := strings.Cut(rule, "=")
+		params[key] = value
+	}
+
+	if options, ok := params["oneof"]; ok && options != "" {
+		first := strings.Fields(options)[0]
+		if goType == "string" {
+			return strconv.Quote(first)
+		}
+		return first
+	}
+
+	switch goType {
+	case "bool":
+		return "true"
+	case "time.Time":
+		return "time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)"
+	case "string":
+		return strconv.Quote(sampleString(params))
+	}
+
+	// Numbers start at 1 (1.5 for floats) and are moved into the range the rules allow
+	value := 1.0
+	if strings.HasPrefix(goType, "float") {
+		value = 1.5
+	}
+	bound := func(key string) (float64, bool) {
+		raw, ok := params[key]
+		if !ok {
+			return 0, false
+		}
+		n, err := strconv.ParseFloat(raw, 64)
+		return n, err == nil
+	}
+	if n, ok := bound("min"); ok && value < n {
+		value = n
+	}
+	if n, ok := bound("gte"); ok && value < n {
+		value = n
+	}
+	if n, ok := bound("gt"); ok && value <= n {
+		value = n + 1
+	}
+	if n, ok := bound("max"); ok && value > n {
+		value = n
+	}
+	if n, ok := bound("lte"); ok && value > n {
+		value = n
+	}
+	if n, ok := bound("lt"); ok && value >= n {
+		value = n - 1
+	}
+	if n, ok := bound("eq"); ok {
+		value = n
+	}
+	if strings.HasPrefix(goType, "float") {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return strconv.FormatInt(int64(value), 10)
+}
+
+// sampleString returns a string that passes the format and length rules of a string field
+func sampleString(params map[string]string) string {
+	formats := []struct {
+		rule   string
+		sample string
+	}{
+		{"email", "user@example.com"},
+		{"url", "https://example.com"},
+		{"http_url", "https://example.com"},
+		{"uri", "https://example.com"},
+		{"uuid", "123e4567-e89b-42d3-a456-426614174000"},
+		{"uuid4", "123e4567-e89b-42d3-a456-426614174000"},
+		{"numeric", "12345"},
+		{"number", "12345"},
+		{"alpha", "sample"},
+		{"alphanum", "sample1"},
+		{"ip", "192.0.2.1"},
+		{"ipv4", "192.0.2.1"},
+		{"hexcolor", "#1a2b3c"},
+	}
+	for _, format := range formats {
+		if _, ok := params[format.rule]; ok {
+			return format.sample
+		}
+	}
+
+	sample := "sample"
+	length := func(key string) (int, bool) {
+		n, err := strconv.Atoi(params[key])
+		return n, err == nil
+	}
+	if n, ok := length("len"); ok {
+		return strings.Repeat("a", n)
+	}
+	for _, key := range []string{"min", "gte"} {
+		if n, ok := length(key); ok && len(sample) < n {
+			sample += strings.Repeat("a", n-len(sample))
+		}
+	}
+	for _, key := range []string{"max", "lte"} {
+		if n, ok := length(key); ok && len(sample) > n {
+			sample = sample[:n]
+		}
+	}
+	return sample
+}
@@ -0,0 +1,36 @@
+package commands
+
+import "testing"
+
+func TestNewFieldTags(t *testing.T) {
+	tests := []struct {
+		spec     string
+		binding  string
+		json     string
+		required bool
+	}{
+		{"name:string:required|min=3", "required,min=3", "name", true},
+		{"nickname:string:max=20", "max=20", "nickname,omitempty", false},
+		{"price:float64:required|gte=0", "gte=0", "price", true},
+		{"quantity:int:required", "", "quantity", true},
+		{"active:bool:required", "", "active", true},
+		{"released_at:time:required", "required", "released_at", true},
+		{"stock:int", "", "stock,omitempty", false},
+	}
+	for _, tt := range tests {
+		fields, err := parseFields(tt.spec)
+		if err != nil {
+			t.Fatalf("parseFields(%q): %v", tt.spec, err)
+		}
+		field := fields[0]
+		if field.BindingTag != tt.binding {
+			t.Errorf("%s: binding tag %q, want %q", tt.spec, field.BindingTag, tt.binding)
+		}
+		if field.JsonTag != tt.json {
+			t.Errorf("%s: json tag %q, want %q", tt.spec, field.JsonTag, tt.json)
+		}
+		if field.Required != tt.required {
+			t.Errorf("%s: required %v, want %v", tt.spec, field.Required, tt.required)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"goi/utils"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Flag variables controlling how generated files are written
+var makeForce bool
+var makeDryRun bool
+var makeDiff bool
+
+// generatedFile is a rendered file waiting to be written to disk
+type generatedFile struct {
+	Path    string // Destination path relative to the project root
+	Content []byte // Rendered file content
+	Label   string // Human readable description, e.g. "Handler 'Product'"
+	Modify  bool   // The file is an in-place edit of an existing project file, which never counts as a conflict
+}
+
+// formatGoSource runs gofmt over rendered Go code. Code that does not parse is an error, so a template
+// or a name that renders invalid Go never reaches the disk.
+func formatGoSource(path string, content []byte) ([]byte, error) {
+	formatted, err := format.Source(content)
+	if err != nil {
+		return nil, fmt.Errorf("generated %s is not valid Go: %w", filepath.ToSlash(path), err)
+	}
+	return formatted, nil
+}
+
+// writeGeneratedFiles writes a set of generated files, honouring --force, --dry-run and --diff.
+// Conflicts are checked for every file before anything is written, so a refused run leaves the tree untouched.
+func writeGeneratedFiles(files []generatedFile) error {
+	var conflicts []string
+	for _, file := range files {
+		existing, err := os.ReadFile(file.Path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("failed to read %s: %w", file.Path, err)
+		}
+		if !file.Modify && !bytes.Equal(existing, file.Content) {
+			conflicts = append(conflicts, file.Path)
+		}
+	}
+
+	// Dry-run: only report what would happen
+	if makeDryRun {
+		for _, file := range files {
+			fmt.Printf("%-10s %s\n", plannedAction(file), file.Path)
+		}
+		return nil
+	}
+
+	// Diff: print a unified diff against what is on disk, without writing
+	if makeDiff {
+		for _, file := range files {
+			existing, err := os.ReadFile(file.Path)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to read %s: %w", file.Path, err)
+			}
+			oldName := "a/" + filepath.ToSlash(file.Path)
+			if os.IsNotExist(err) {
+				oldName = "/dev/null"
+			}
+			fmt.Print(unifiedDiff(oldName, "b/"+filepath.ToSlash(file.Path), string(existing), string(file.Content)))
+		}
+		return nil
+	}
+
+	if len(conflicts) > 0 && !makeForce {
+		return fmt.Errorf("refusing to overwrite existing files (use --force to overwrite, --diff to compare):\n  %s", strings.Join(conflicts, "\n  "))
+	}
+
+	for _, file := range files {
+		action := plannedAction(file)
+		if action == "unchanged" {
+			utils.PrintInfo(fmt.Sprintf("%s is up to date (%s)", file.Label, file.Path))
+			continue
+		}
+
+		if err := ensureDirectoryExists(filepath.Dir(file.Path)); err != nil {
+			return err
+		}
+		if err := os.WriteFile(file.Path, file.Content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.Path, err)
+		}
+		switch action {
+		case "overwrite":
+			utils.PrintWarning(fmt.Sprintf("%s overwritten (%s)", file.Label, file.Path))
+		case "update":
+			utils.PrintSuccess(fmt.Sprintf("%s added to %s", file.Label, file.Path))
+		default:
+			utils.PrintSuccess(fmt.Sprintf("%s created successfully", file.Label))
+		}
+	}
+
+	return nil
+}
+
+// plannedAction describes what writing the file would do: create, update, overwrite, conflict or unchanged
+func plannedAction(file generatedFile) string {
+	existing, err := os.ReadFile(file.Path)
+	switch {
+	case err != nil:
+		return "create"
+	case bytes.Equal(existing, file.Content):
+		return "unchanged"
+	case file.Modify:
+		return "update"
+	case makeForce:
+		return "overwrite"
+	default:
+		return "conflict"
+	}
+}
@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// makeHTTP holds the --http flag of the generators that render handlers, responses, middleware and routes
+var makeHTTP string
+
+// defaultHTTPFramework is used when neither --http, goi.yaml nor go.mod selects a framework
+const defaultHTTPFramework = "gin"
+
+// httpFramework describes the router the generated handlers, responses, middleware and routes are written for
+type httpFramework struct {
+	Name        string   // gin, echo, chi, fiber or stdlib
+	Module      string   // go.mod requirement that selects the framework, empty for stdlib
+	Template    string   // Suffix of the framework's templates, e.g. "echo" for handler_echo; gin uses the plain names
+	RouterTypes []string // Parameter types of a router setup function, the first one is used by the route template
+	RouterFuncs []string // Constructors of a router created inside the setup function, e.g. gin.Default
+}
+
+// httpFrameworks lists the supported frameworks. chi and stdlib handlers are plain net/http handlers,
+// so they share the nethttp templates, which branch on .HTTP.Name for URL parameters and routing.
+var httpFrameworks = map[string]httpFramework{
+	"gin": {
+		Name:        "gin",
+		Module:      "github.com/gin-gonic/gin",
+		RouterTypes: []string{"*gin.Engine", "*gin.RouterGroup", "gin.IRouter", "gin.IRoutes"},
+		RouterFuncs: []string{"gin.Default", "gin.New"},
+	},
+	"echo": {
+		Name:        "echo",
+		Module:      "github.com/labstack/echo/v4",
+		Template:    "echo",
+		RouterTypes: []string{"*echo.Echo", "*echo.Group"},
+		RouterFuncs: []string{"echo.New"},
+	},
+	"chi": {
+		Name:        "chi",
+		Module:      "github.com/go-chi/chi/v5",
+		Template:    "nethttp",
+		RouterTypes: []string{"chi.Router", "*chi.Mux"},
+		RouterFuncs: []string{"chi.NewRouter", "chi.NewMux"},
+	},
+	"fiber": {
+		Name:        "fiber",
+		Module:      "github.com/gofiber/fiber/v2",
+		Template:    "fiber",
+		RouterTypes: []string{"*fiber.App", "fiber.Router"},
+		RouterFuncs: []string{"fiber.New"},
+	},
+	"stdlib": {
+		Name:        "stdlib",
+		Template:    "nethttp",
+		RouterTypes: []string{"*http.ServeMux"},
+		RouterFuncs: []string{"http.NewServeMux"},
+	},
+}
+
+// httpFrameworkAliases are accepted spellings of the framework names
+var httpFrameworkAliases = map[string]string{
+	"net/http": "stdlib",
+	"nethttp":  "stdlib",
+	"http":     "stdlib",
+}
+
+// httpDetectionOrder is the order go.mod requirements are checked in when a project requires several routers
+var httpDetectionOrder = []string{"gin", "echo", "chi", "fiber"}
+
+// selectedHTTPFramework returns the framework chosen with --http, falling back to the http setting of goi.yaml,
+// then to the router required by the project's go.mod and finally to gin
+func selectedHTTPFramework() (httpFramework, error) {
+	name := makeHTTP
+	if name == "" {
+		config, err := loadProjectConfig()
+		if err != nil {
+			return httpFramework{}, err
+		}
+		name = config.HTTP
+	}
+	if name == "" {
+		name = detectHTTPFramework("go.mod")
+	}
+	if name == "" {
+		name = defaultHTTPFramework
+	}
+	if alias, ok := httpFrameworkAliases[name]; ok {
+		name = alias
+	}
+
+	framework, ok := httpFrameworks[name]
+	if !ok {
+		return httpFramework{}, fmt.Errorf("unknown HTTP framework %q, expected one of: %s", name, strings.Join(httpFrameworkNames(), ", "))
+	}
+	return framework, nil
+}
+
+// detectHTTPFramework returns the framework whose module go.mod requires directly, or "" if it requires none.
+// Indirect requirements are only dependencies of other modules, so they say nothing about the project's router.
+func detectHTTPFramework(goModPath string) string {
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return ""
+	}
+
+	required := map[string]bool{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.Contains(line, "// indirect") {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), "require "))
+		if len(fields) >= 2 {
+			required[fields[0]] = true
+		}
+	}
+	for _, name := range httpDetectionOrder {
+		if required[httpFrameworks[name].Module] {
+			return name
+		}
+	}
+	return ""
+}
+
+// httpFrameworkNames returns the supported framework names, sorted
+func httpFrameworkNames() []string {
+	names := make([]string, 0, len(httpFrameworks))
+	for name := range httpFrameworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// httpTemplateName maps a template name to the variant written for the framework.
+// Only handlers, their tests, responses, middleware and routes depend on the framework.
+func httpTemplateName(name string, framework httpFramework) string {
+	if framework.Template == "" {
+		return name
+	}
+	switch {
+	case name == "handler", name == "handler_test", name == "route",
+		strings.HasSuffix(name, "_response"), strings.HasPrefix(name, "middleware_"):
+		return name + "_" + framework.Template
+	}
+	return name
+}
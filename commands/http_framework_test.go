@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectHTTPFrameworkIgnoresIndirectRequirements(t *testing.T) {
+	tests := []struct {
+		name  string
+		goMod string
+		want  string
+	}{
+		{
+			name:  "direct",
+			goMod: "module example.com/shop\n\nrequire github.com/labstack/echo/v4 v4.12.0\n",
+			want:  "echo",
+		},
+		{
+			name:  "indirect only",
+			goMod: "module example.com/shop\n\nrequire (\n\tgithub.com/gin-gonic/gin v1.10.0 // indirect\n)\n",
+			want:  "",
+		},
+		{
+			name:  "direct next to indirect",
+			goMod: "module example.com/shop\n\nrequire (\n\tgithub.com/go-chi/chi/v5 v5.1.0\n\tgithub.com/gin-gonic/gin v1.10.0 // indirect\n)\n",
+			want:  "chi",
+		},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "go.mod")
+		if err := os.WriteFile(path, []byte(tt.goMod), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if got := detectHTTPFramework(path); got != tt.want {
+			t.Errorf("%s: detectHTTPFramework = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"go/build"
+	"goi/utils"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// mainPackage is a directory of the module holding a 'package main'
+type mainPackage struct {
+	Name string // Last element of the directory, or the binary name for the module root
+	Dir  string // Slash-separated path relative to the module root, "." for the root
+}
+
+// target returns the package argument for 'go build'
+func (p mainPackage) target() string {
+	if p.Dir == "." {
+		return "."
+	}
+	return "./" + p.Dir
+}
+
+func (p mainPackage) String() string {
+	return fmt.Sprintf("%s (%s)", p.Name, p.target())
+}
+
+// preferredMainDirs are picked, in order, when several main packages exist and goi cannot ask which one to use
+var preferredMainDirs = []string{"cmd/api", "internal/server", "."}
+
+// discoverMainPackages returns the main packages of the module, sorted by directory.
+// Hidden directories, vendor, testdata, node_modules and the build output are skipped.
+func discoverMainPackages(root string, config *projectConfig) ([]mainPackage, error) {
+	output := filepath.ToSlash(filepath.Clean(config.Build.Output))
+	var packages []mainPackage
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel != "." && (strings.HasPrefix(d.Name(), ".") || watchIgnoredDirs[d.Name()] || rel == output) {
+			return filepath.SkipDir
+		}
+
+		pkg, err := build.ImportDir(p, 0)
+		if err != nil {
+			// Directories without Go files, or mixing packages, cannot be built
+			var noGo *build.NoGoError
+			var multiple *build.MultiplePackageError
+			if errors.As(err, &noGo) || errors.As(err, &multiple) {
+				return nil
+			}
+			return fmt.Errorf("failed to read package %s: %w", rel, err)
+		}
+		if !pkg.IsCommand() {
+			return nil
+		}
+		name := d.Name()
+		if rel == "." {
+			name = defaultBinaryName()
+		}
+		packages = append(packages, mainPackage{Name: name, Dir: rel})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s for main packages: %w", root, err)
+	}
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Dir < packages[j].Dir })
+	return packages, nil
+}
+
+// findMainPackage returns the main package with the given name or directory
+func findMainPackage(packages []mainPackage, name string) (mainPackage, error) {
+	dir := strings.TrimPrefix(filepath.ToSlash(filepath.Clean(name)), "./")
+	for _, pkg := range packages {
+		if pkg.Dir == dir {
+			return pkg, nil
+		}
+	}
+	var matches []mainPackage
+	for _, pkg := range packages {
+		if pkg.Name == name {
+			matches = append(matches, pkg)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return mainPackage{}, fmt.Errorf("no main package named %q, available: %s", name, describeMainPackages(packages))
+	case 1:
+		return matches[0], nil
+	default:
+		return mainPackage{}, fmt.Errorf("several main packages are named %q, use the directory instead: %s", name, describeMainPackages(matches))
+	}
+}
+
+// resolveMainPackage selects the main package run by serve and compiled by build: the one named on the
+// command line, the main setting of goi.yaml, or the only main package of the module. When there are
+// several, the user picks one on a terminal; otherwise cmd/api, internal/server or the root is used.
+func resolveMainPackage(root, name string, config *projectConfig) (mainPackage, error) {
+	if name == "" && config.Main != "" {
+		dir := mainPackageDir(config.Main)
+		pkg := mainPackage{Name: filepath.Base(dir), Dir: dir}
+		if dir == "." {
+			pkg.Name = defaultBinaryName()
+		}
+		return pkg, nil
+	}
+
+	packages, err := discoverMainPackages(root, config)
+	if err != nil {
+		return mainPackage{}, err
+	}
+	if name != "" {
+		return findMainPackage(packages, name)
+	}
+
+	switch len(packages) {
+	case 0:
+		return mainPackage{}, fmt.Errorf("no main package found in %s", root)
+	case 1:
+		return packages[0], nil
+	}
+
+	if utils.IsInteractive() {
+		options := make([]string, len(packages))
+		for i, pkg := range packages {
+			options[i] = pkg.String()
+		}
+		choice, err := utils.Choose("Several main packages found, which one?", options)
+		if err != nil {
+			return mainPackage{}, err
+		}
+		return packages[choice], nil
+	}
+	for _, dir := range preferredMainDirs {
+		for _, pkg := range packages {
+			if pkg.Dir == dir {
+				return pkg, nil
+			}
+		}
+	}
+	return mainPackage{}, fmt.Errorf("several main packages found, name one of: %s", describeMainPackages(packages))
+}
+
+// mainPackageDir returns the slash-separated package directory of a main file or package directory
+func mainPackageDir(main string) string {
+	return filepath.ToSlash(filepath.Clean(strings.TrimPrefix(buildPackage(main), "./")))
+}
+
+// describeMainPackages lists main packages as "name (./dir)"
+func describeMainPackages(packages []mainPackage) string {
+	names := make([]string, len(packages))
+	for i, pkg := range packages {
+		names[i] = pkg.String()
+	}
+	return strings.Join(names, ", ")
+}
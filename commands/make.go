@@ -1,10 +1,12 @@
 package commands
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
-	"goi/templates"
 	"goi/utils"
 	"os"
+	"path/filepath"
 	"strings"
 	"text/template"
 
@@ -13,6 +15,24 @@ import (
 	"golang.org/x/text/language"
 )
 
+// makeFields holds the raw --fields specification shared by the make subcommands
+var makeFields string
+
+// makeNoRoutes skips route registration when generating a resource
+var makeNoRoutes bool
+
+// makeNoVerify skips compiling the generated resource in a temporary module before writing it.
+// makeVerify is the former opt-in flag, kept as a no-op so existing scripts keep working.
+var makeNoVerify bool
+var makeVerify bool
+
+// makeNoTests skips the handler and repository tests generated with a resource
+var makeNoTests bool
+
+// makeNoWire skips dependency-injection wiring, makeWireFile selects the file that is wired
+var makeNoWire bool
+var makeWireFile string
+
 // MakeCmd represents the 'make' command
 var MakeCmd = &cobra.Command{
 	Use:   "make",
@@ -31,56 +51,138 @@ func init() {
 	MakeCmd.AddCommand(MakeRepositoryCmd)
 	MakeCmd.AddCommand(MakeResponseCmd)
 	MakeCmd.AddCommand(MakeResourceCmd)
+	MakeCmd.AddCommand(MakeMiddlewareCmd)
+	MakeCmd.AddCommand(MakeMigrationCmd)
+	MakeCmd.AddCommand(MakeVersionCmd)
+	MakeCmd.AddCommand(MakeTemplateCmd)
+	MakeCmd.AddCommand(MakeVerifyCmd)
+
+	// Field definitions are shared by every generator that renders the resource's fields
+	for _, cmd := range []*cobra.Command{MakeHandlerCmd, MakeDTOCmd, MakeModelCmd, MakeServiceCmd, MakeRepositoryCmd, MakeResourceCmd, MakeMigrationCmd} {
+		cmd.Flags().StringVar(&makeFields, "fields", "", `Field definitions, e.g. "name:string:required,price:float64:gte=0,sku:string:unique"`)
+		cmd.Flags().StringVar(&makeFrom, "from", "", "Read the fields from an OpenAPI or JSON Schema document, e.g. openapi.yaml#/components/schemas/Order")
+		cmd.Flags().StringVar(&makeFromTable, "from-table", "", "Read the fields from an existing MySQL or SQLite table")
+		cmd.Flags().StringVar(&makeDSN, "dsn", "", "Database to read --from-table from, e.g. user:pass@tcp(localhost:3306)/shop or sqlite://dev.db (defaults to the DB_* settings in .env)")
+	}
+
+	// Models and resources can come with the migration that creates their table
+	for _, cmd := range []*cobra.Command{MakeModelCmd, MakeResourceCmd} {
+		cmd.Flags().BoolVar(&makeMigration, "migration", false, "Also generate the migration that creates the table")
+	}
+
+	// The HTTP framework decides how handlers read requests and write responses, and how routes are registered
+	for _, cmd := range []*cobra.Command{MakeHandlerCmd, MakeResponseCmd, MakeResourceCmd, MakeMiddlewareCmd, MakeVerifyCmd} {
+		cmd.Flags().StringVar(&makeHTTP, "http", "", "HTTP framework: "+strings.Join(httpFrameworkNames(), ", ")+" (defaults to the http setting of goi.yaml, then the router required in go.mod, then gin)")
+	}
+
+	// The repository backend decides how models are mapped and how repositories query the database
+	for _, cmd := range []*cobra.Command{MakeModelCmd, MakeRepositoryCmd, MakeResourceCmd, MakeVerifyCmd} {
+		cmd.Flags().StringVar(&makeORM, "orm", "", "Repository backend: "+strings.Join(ormNames(), ", ")+" (defaults to the orm setting of goi.yaml, then gorm)")
+	}
+
+	MakeResourceCmd.Flags().BoolVar(&makeNoVerify, "no-verify", false, "Do not compile, vet and test the generated resource in a temporary module before writing it")
+	MakeResourceCmd.Flags().BoolVar(&makeVerify, "verify", false, "Verify the generated resource before writing it")
+	_ = MakeResourceCmd.Flags().MarkDeprecated("verify", "verification runs by default, use --no-verify to skip it")
+	MakeResourceCmd.Flags().BoolVar(&makeNoTests, "no-tests", false, "Do not generate the handler and repository tests")
+	MakeResourceCmd.Flags().BoolVar(&makeNoRoutes, "no-routes", false, "Do not register the resource routes in the router setup file")
+	MakeResourceCmd.Flags().BoolVar(&makeNoWire, "no-wire", false, "Do not wire the repository, service and handler into the wiring file")
+	MakeResourceCmd.Flags().StringVar(&makeWireFile, "wire-file", "", "Wiring file to update (a Container struct, wire.NewSet or fx.Provide provider set; defaults to layout.container of goi.yaml)")
+
+	// Overwrite protection applies to every generator
+	MakeCmd.PersistentFlags().BoolVarP(&makeForce, "force", "f", false, "Overwrite files that already exist")
+	MakeCmd.PersistentFlags().BoolVar(&makeDryRun, "dry-run", false, "Print the files that would be generated without writing them")
+	MakeCmd.PersistentFlags().BoolVar(&makeDiff, "diff", false, "Show a unified diff between existing files and the newly rendered ones without writing them")
 }
 
 // MakeHandlerCmd generates a new handler file
 var MakeHandlerCmd = &cobra.Command{
-	Use:   "handler <name>",
+	Use:   "handler [name]",
 	Short: "Generate a new handler",
-	Args:  cobra.ExactArgs(1),
+	Args:  nameOrSourceArgs("handler"),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return generateFile(args[0], "handler")
+		name := nameFromArgs(args)
+		fields, err := loadFields()
+		if err != nil {
+			return err
+		}
+		return generateFile(name, "handler", fields)
 	},
 }
 
 // MakeDTOCmd generates a new dto file
 var MakeDTOCmd = &cobra.Command{
-	Use:   "dto <name>",
+	Use:   "dto [name]",
 	Short: "Generate a new dto",
-	Args:  cobra.ExactArgs(1),
+	Args:  nameOrSourceArgs("dto"),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return generateFile(args[0], "dto")
+		name := nameFromArgs(args)
+		fields, err := loadFields()
+		if err != nil {
+			return err
+		}
+		return generateFile(name, "dto", fields)
 	},
 }
 
 
 // MakeModelCmd generates a new model file
 var MakeModelCmd = &cobra.Command{
-	Use:   "model <name>",
+	Use:   "model [name]",
 	Short: "Generate a new model",
-	Args:  cobra.ExactArgs(1),
+	Args:  nameOrSourceArgs("model"),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return generateFile(args[0], "model")
+		name := nameFromArgs(args)
+		fields, err := loadFields()
+		if err != nil {
+			return err
+		}
+		if !makeMigration {
+			return generateFile(name, "model", fields)
+		}
+
+		moduleName, err := getModuleNameFromGoMod(".")
+		if err != nil {
+			return fmt.Errorf("failed to get module name from go.mod: %w", err)
+		}
+		model, err := renderResourceFile(name, "model", moduleName, fields)
+		if err != nil {
+			return err
+		}
+		migrationFiles, err := renderCreateTableMigration(name, fields)
+		if err != nil {
+			return err
+		}
+		return writeResourceFiles(name, append([]generatedFile{model}, migrationFiles...))
 	},
 }
 
 // MakeServiceCmd generates a new service file
 var MakeServiceCmd = &cobra.Command{
-	Use:   "service <name>",
+	Use:   "service [name]",
 	Short: "Generate a new service",
-	Args:  cobra.ExactArgs(1),
+	Args:  nameOrSourceArgs("service"),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return generateFile(args[0], "service")
+		name := nameFromArgs(args)
+		fields, err := loadFields()
+		if err != nil {
+			return err
+		}
+		return generateFile(name, "service", fields)
 	},
 }
 
 // MakeRepositoryCmd generates a new repository file
 var MakeRepositoryCmd = &cobra.Command{
-	Use:   "repository <name>",
+	Use:   "repository [name]",
 	Short: "Generate a new repository",
-	Args:  cobra.ExactArgs(1),
+	Args:  nameOrSourceArgs("repository"),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return generateFile(args[0], "repository")
+		name := nameFromArgs(args)
+		fields, err := loadFields()
+		if err != nil {
+			return err
+		}
+		return generateFile(name, "repository", fields)
 	},
 }
 
@@ -93,127 +195,337 @@ var MakeResponseCmd = &cobra.Command{
 
 // MakeResourceCmd generates a new resource (handler, dto, model, service, repository)
 var MakeResourceCmd = &cobra.Command{
-	Use:   "resource <name>",
+	Use:   "resource [name]",
 	Short: "Generate a new resource with handler, dto, model, service, and repository",
-	Args:  cobra.ExactArgs(1), // We expect exactly one argument: the name of the resource
-	RunE:  generateResource,
+	Long: `The 'resource' command generates the model, DTO, handler, service and repository of
+a resource, registers its routes and wires its dependencies.
+
+Fields come from --fields, or from an existing API contract with --from, which reads
+the properties of an OpenAPI or JSON Schema object (YAML or JSON) and turns their
+types, formats, enums and bounds into Go types and binding tags:
+
+  goi make resource --from openapi.yaml#/components/schemas/Order
+  goi make resource Customer --from schemas/customer.schema.json
+
+A resource can also be generated from an existing MySQL table, or a SQLite stand-in,
+with --from-table. Column types, nullability, lengths, enums and single-column
+indexes become the fields; the model keeps the table's name, primary key column
+and timestamp columns instead of embedding gorm.Model:
+
+  goi make resource --from-table customers --dsn "user:pass@tcp(localhost:3306)/shop"
+  goi make resource --from-table customers --dsn sqlite://legacy.db
+
+The resource name defaults to the last segment of the schema reference, or the table name.
+
+Before anything is written, the generated code is compiled, vetted and tested in a
+temporary module. Resolving its dependencies needs the module proxy or a warm module
+cache; when they cannot be downloaded the verification is skipped with a warning.
+Use --no-verify to skip it.`,
+	Args: nameOrSourceArgs("resource"),
+	RunE: generateResource,
+}
+
+// nameOrSourceArgs accepts a name, or no argument when the name can be derived from the schema
+// given with --from or the table given with --from-table
+func nameOrSourceArgs(kind string) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 && makeFrom == "" && makeFromTable == "" {
+			return fmt.Errorf("requires a %s name, or a schema or table to read it from with --from or --from-table", kind)
+		}
+		return cobra.MaximumNArgs(1)(cmd, args)
+	}
+}
+
+// nameFromArgs returns the name argument, or the name derived from --from or --from-table,
+// e.g. "customers" -> "Customer"
+func nameFromArgs(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	name := makeFromTable
+	if makeFrom != "" {
+		name = schemaRefName(makeFrom)
+	}
+	return utils.Pascal(utils.Singular(name))
 }
 
 // generateResource creates all files for a resource (handler, dto, model, service, repository)
 func generateResource(cmd *cobra.Command, args []string) error {
-	resourceName := args[0]
+	// Normalise the name once, e.g. "order_items" -> "OrderItem", so routes and wiring match the generated types
+	resourceName := utils.Pascal(utils.Singular(nameFromArgs(args)))
 
-	// Create handler file
-	if err := generateFile(resourceName, "handler"); err != nil {
+	// Parse the field definitions once so every generated file agrees on them
+	fields, err := loadFields()
+	if err != nil {
 		return err
 	}
 
-	// Create dto file
-	if err := generateFile(resourceName, "dto"); err != nil {
-		return err
+	moduleName, err := getModuleNameFromGoMod(".")
+	if err != nil {
+		return fmt.Errorf("failed to get module name from go.mod: %w", err)
 	}
 
-	// Create model file
-	if err := generateFile(resourceName, "model"); err != nil {
+	// Render every layer first so conflicts are detected before anything is written
+	files, err := renderResourceFiles(resourceName, moduleName, fields)
+	if err != nil {
 		return err
 	}
 
-	// Create service file
-	if err := generateFile(resourceName, "service"); err != nil {
-		return err
+	// Add the migration that creates the resource's table
+	if makeMigration {
+		migrationFiles, err := renderCreateTableMigration(resourceName, fields)
+		if err != nil {
+			return err
+		}
+		files = append(files, migrationFiles...)
+	}
+
+	// Make sure the generated layers compile together before touching the project.
+	// Without network access dependencies cannot be resolved, which skips verification rather than failing.
+	if !makeNoVerify {
+		var skipped *verifySkippedError
+		if err := verifyResource(resourceName, moduleName, fields); errors.As(err, &skipped) {
+			utils.PrintWarning(skipped.Error())
+		} else if err != nil {
+			return err
+		}
+	}
+
+	// Construct and inject the repository, service and handler in the wiring file
+	var container *containerRef
+	if !makeNoWire {
+		wireFile := makeWireFile
+		if wireFile == "" {
+			wireFile = projectLayout().Container
+		}
+		wiring, err := wireResource(resourceName, moduleName, wireFile)
+		if err != nil {
+			return err
+		}
+		if wiring != nil {
+			files = append(files, *wiring)
+		} else {
+			utils.PrintInfo(fmt.Sprintf("'%s' is already wired in %s", resourceName, wireFile))
+		}
+		// Routes take the wired handler from the container instead of building their own
+		if container, err = wiredContainer(wireFile, moduleName); err != nil {
+			return err
+		}
 	}
 
-	// Create repository file
-	if err := generateFile(resourceName, "repository"); err != nil {
+	// Register the CRUD routes in the project's router setup
+	if !makeNoRoutes {
+		routes, err := registerResourceRoutes(resourceName, moduleName, container)
+		if err != nil {
+			return err
+		}
+		if routes != nil {
+			files = append(files, *routes)
+		} else {
+			utils.PrintInfo(fmt.Sprintf("Routes for '%s' are already registered", resourceName))
+		}
+	}
+
+	if err := writeResourceFiles(resourceName, files); err != nil {
 		return err
 	}
 
 	// Success message
-	utils.PrintSuccess(fmt.Sprintf("Resource '%s' generated successfully!", resourceName))
+	if !makeDryRun && !makeDiff {
+		utils.PrintSuccess(fmt.Sprintf("Resource '%s' generated successfully!", resourceName))
+	}
 	return nil
 }
 
 // generateFile creates files based on the provided resource type and name
-func generateFile(resourceName, resourceType string) error {
-	// Ensure the directory exists for the resource type
-	dir := getDirectoryForResource(resourceType)
-	if err := ensureDirectoryExists(dir); err != nil {
-		return err
-	}
-
+func generateFile(resourceName, resourceType string, fields []Field) error {
 	// Get the module name dynamically from the go.mod file
 	moduleName, err := getModuleNameFromGoMod(".") // Assuming the go.mod is in the current directory
 	if err != nil {
 		return fmt.Errorf("failed to get module name from go.mod: %w", err)
 	}
 
-	// Parse the appropriate template
-	tmpl, err := parseTemplateForResource(resourceType)
+	file, err := renderResourceFile(resourceName, resourceType, moduleName, fields)
 	if err != nil {
 		return err
 	}
+	return writeResourceFiles(resourceName, []generatedFile{file})
+}
+
+// renderResourceFiles renders the handler, dto, model, service and repository of a resource, and their tests
+func renderResourceFiles(resourceName, moduleName string, fields []Field) ([]generatedFile, error) {
+	resourceTypes := []string{"handler", "dto", "model", "service", "repository"}
+	if !makeNoTests {
+		resourceTypes = append(resourceTypes, "handler_test", "repository_test")
+	}
+
+	backend, err := selectedORM()
+	if err != nil {
+		return nil, err
+	}
+
+	var files []generatedFile
+	for _, resourceType := range resourceTypes {
+		if ormTemplateName(resourceType, backend) == "" {
+			utils.PrintInfo(fmt.Sprintf("No %s generated, %s repositories need a PostgreSQL database to be tested against", strings.ReplaceAll(resourceType, "_", " "), backend.Name))
+			continue
+		}
+		file, err := renderResourceFile(resourceName, resourceType, moduleName, fields)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// renderResourceFile renders the template of the given resource type without touching the disk
+func renderResourceFile(resourceName, resourceType, moduleName string, fields []Field) (generatedFile, error) {
+	dir := getDirectoryForResource(resourceType)
+
+	// Models and repositories are rendered for the selected ORM backend, handlers for the selected HTTP framework
+	backend, err := selectedORM()
+	if err != nil {
+		return generatedFile{}, err
+	}
+	framework, err := selectedHTTPFramework()
+	if err != nil {
+		return generatedFile{}, err
+	}
+	templateName := ormTemplateName(resourceType, backend)
+	if templateName == "" {
+		return generatedFile{}, fmt.Errorf("the %s backend has no %s template", backend.Name, strings.ReplaceAll(resourceType, "_", " "))
+	}
+	templateName = httpTemplateName(templateName, framework)
 
-	// Create the file
-	fileName := fmt.Sprintf("%s/%s_%s.go", dir, strings.ToLower(resourceName), strings.ToLower(resourceType))
-	file, err := os.Create(fileName)
+	// Parse the appropriate template
+	tmpl, err := parseTemplateForResource(templateName)
 	if err != nil {
-		return fmt.Errorf("failed to create %s file: %w", resourceType, err)
+		return generatedFile{}, err
 	}
-	defer file.Close()
 
 	// Capitalize the resourceType using cases.Title (proper Unicode handling)
-	titleCase := cases.Title(language.Und, cases.Compact).String(resourceType)
+	titleCase := cases.Title(language.Und, cases.Compact).String(strings.ReplaceAll(resourceType, "_", " "))
 
-	// Generate content from the template, passing the ModuleName along with the resource name
-	err = tmpl.Execute(file, map[string]string{
-		titleCase + "Name":      resourceName,
-		"ModuleName":   moduleName,
-	})
+	// Generate content from the template, passing the ModuleName and fields along with the inflected resource names
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, resourceTemplateData(resourceName, moduleName, fields, backend, framework))
 	if err != nil {
-		return fmt.Errorf("failed to write to %s file: %w", resourceType, err)
+		return generatedFile{}, fmt.Errorf("failed to render %s template: %w", resourceType, err)
 	}
 
-	utils.PrintSuccess(fmt.Sprintf("%s '%s' created successfully", titleCase, resourceName))
-	return nil
+	path := filepath.Join(dir, fmt.Sprintf("%s_%s.go", utils.Snake(resourceName), strings.ToLower(resourceType)))
+	content, err := formatGoSource(path, buf.Bytes())
+	if err != nil {
+		return generatedFile{}, err
+	}
+	return generatedFile{
+		Path:    path,
+		Content: content,
+		Label:   fmt.Sprintf("%s '%s'", titleCase, utils.Pascal(utils.Singular(resourceName))),
+	}, nil
 }
 
-// parseTemplateForResource selects the appropriate template for the resource type
-func parseTemplateForResource(resourceType string) (*template.Template, error) {
-	var tmplContent string
+// resourceTemplateData returns the values available to every resource template.
+// Names are inflected once here so all generated files agree on identifiers, paths and table names.
+func resourceTemplateData(resourceName, moduleName string, fields []Field, backend ormBackend, framework httpFramework) map[string]interface{} {
+	if backend.Name != defaultORM {
+		fields = sqlScanFields(fields)
+	}
+	name := utils.Pascal(utils.Singular(resourceName))
+	data := map[string]interface{}{
+		"Name":        name,                            // OrderItem
+		"NamePlural":  utils.Plural(name),              // OrderItems
+		"Camel":       utils.Camel(name),               // orderItem
+		"CamelPlural": utils.Camel(utils.Plural(name)), // orderItems
+		"Var":         utils.VarName(name),             // orderItem, never a Go keyword
+		"VarPlural":   utils.VarName(utils.Plural(name)),
+		"Snake":       utils.Snake(name),               // order_item
+		"SnakePlural": utils.Snake(utils.Plural(name)), // order_items
+		"Kebab":       utils.Kebab(name),               // order-item
+		"KebabPlural": utils.Kebab(utils.Plural(name)), // order-items
+		"Table":       utils.TableName(name),           // order_items
+		"ModuleName":  moduleName,
+		"Imports":     newPackageImports(moduleName, projectLayout()),
+		"Fields":      fields,
+		"NeedsTime":   fieldsNeedTime(fields),
+		"BaseFields":  []Field(nil), // Replace the embedded gorm.Model when set
+		"BaseTime":    false,
+		"PrimaryKey":  "id",
+		"NeedsGorm":   true,
+		"ORM":         backend.Name,
+		"DBType":      backend.DBType,
+		"DBImport":    backend.DBImport,
+		"HTTP":        framework,
+	}
 
-	// Choose the correct template based on resourceType
-	switch resourceType {
-	case "handler":
-		tmplContent = templates.HandlerTemplate
-	case "dto":
-		tmplContent = templates.DTOTemplate
-	case "model":
-		tmplContent = templates.ModelTemplate
-	case "service":
-		tmplContent = templates.ServiceTemplate
-	case "repository":
-		tmplContent = templates.RepositoryTemplate
-	default:
-		return nil, fmt.Errorf("unknown resource type: %s", resourceType)
+	// Models of existing tables keep the table's name, primary key and timestamp columns
+	if sourceTable != nil {
+		data["Table"] = sourceTable.Name
+		data["BaseFields"] = sourceTable.BaseFields
+		data["BaseTime"] = fieldsNeedTime(sourceTable.BaseFields)
+		data["PrimaryKey"] = sourceTable.BaseFields[0].ColumnName
+		data["NeedsGorm"] = false
+		for _, field := range sourceTable.BaseFields {
+			if strings.HasPrefix(field.FieldType, "gorm.") {
+				data["NeedsGorm"] = true
+			}
+		}
+	}
+
+	// Backends without gorm.Model declare the ID and timestamp columns themselves and write their own SQL
+	if backend.Name != defaultORM {
+		base := defaultSQLBaseFields()
+		if sourceTable != nil {
+			base = append([]Field{}, sourceTable.BaseFields...)
+			for i := range base {
+				if base[i].FieldType == "gorm.DeletedAt" {
+					base[i].FieldType = "*time.Time"
+				}
+			}
+		}
+		data["BaseFields"] = base
+		data["BaseTime"] = fieldsNeedTime(base)
+		data["NeedsGorm"] = false
+		data["SQL"] = newSQLTable(backend, data["Table"].(string), base, fields)
+	}
+
+	// Per-type names kept for templates written against the original placeholders
+	for _, key := range []string{"HandlerName", "DtoName", "ModelName", "ServiceName", "RepositoryName", "RouteName"} {
+		data[key] = name
+	}
+	return data
+}
+
+// parseTemplateForResource loads the template for the resource type, preferring user overrides over the built-ins
+func parseTemplateForResource(resourceType string) (*template.Template, error) {
+	tmplContent, source, err := loadTemplate(resourceType)
+	if err != nil {
+		return nil, err
 	}
 
 	// Parse and return the template
-	return template.New(resourceType).Parse(tmplContent)
+	tmpl, err := template.New(resourceType).Parse(tmplContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s template (%s): %w", resourceType, source, err)
+	}
+	return tmpl, nil
 }
 
-// getDirectoryForResource returns the correct directory based on the resource type
+// getDirectoryForResource returns the directory of the resource type in the project layout (see goi.yaml)
 func getDirectoryForResource(resourceType string) string {
+	layout := projectLayout()
 	switch resourceType {
-	case "handler":
-		return "handlers"
+	case "handler", "handler_test":
+		return layout.Handlers
 	case "dto":
-		return "dto"
+		return layout.DTO
 	case "model":
-		return "models"
+		return layout.Models
 	case "service":
-		return "services"
-	case "repository":
-		return "repository"
+		return layout.Services
+	case "repository", "repository_test":
+		return layout.Repository
 	default:
 		return ""
 	}
@@ -221,37 +533,49 @@ func getDirectoryForResource(resourceType string) string {
 
 // generateResponse creates response files based on templates
 func generateResponse(cmd *cobra.Command, args []string) error {
-	responseFiles := map[string]string{
-		"success_response.go":       templates.SuccessResponseTemplate,       // Already exists (SuccessResponse)
-		"error_response.go":         templates.ErrorResponseTemplate,         // Already exists (ErrorResponse)
-		"pagination_response.go":    templates.PaginationResponseTemplate,    // Already exists (Pagination)
+	files, err := renderResponseFiles()
+	if err != nil {
+		return err
 	}
+	return writeGeneratedFiles(files)
+}
 
-	if err := ensureDirectoryExists("response"); err != nil {
-		return err
+// renderResponseFiles renders the success, error and pagination response helpers
+func renderResponseFiles() ([]generatedFile, error) {
+	responseTemplates := []string{"success_response", "error_response", "pagination_response"}
+
+	framework, err := selectedHTTPFramework()
+	if err != nil {
+		return nil, err
 	}
 
-	for fileName, tmplContent := range responseFiles {
-		tmpl, err := template.New(fileName).Parse(tmplContent)
+	dir := projectLayout().Response
+	var files []generatedFile
+	for _, name := range responseTemplates {
+		fileName := name + ".go"
+		tmpl, err := parseTemplateForResource(httpTemplateName(name, framework))
 		if err != nil {
-			return fmt.Errorf("failed to parse response template %s: %w", fileName, err)
+			return nil, err
 		}
 
-		filePath := fmt.Sprintf("response/%s", fileName)
-		file, err := os.Create(filePath)
-		if err != nil {
-			return fmt.Errorf("failed to create response file %s: %w", filePath, err)
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, map[string]interface{}{"HTTP": framework}); err != nil {
+			return nil, fmt.Errorf("failed to render response file %s: %w", fileName, err)
 		}
-		defer file.Close()
 
-		if err := tmpl.Execute(file, nil); err != nil {
-			return fmt.Errorf("failed to write to response file %s: %w", filePath, err)
+		path := filepath.Join(dir, fileName)
+		content, err := formatGoSource(path, buf.Bytes())
+		if err != nil {
+			return nil, err
 		}
-
-		fmt.Printf("Response file '%s' created successfully\n", fileName)
+		files = append(files, generatedFile{
+			Path:    path,
+			Content: content,
+			Label:   fmt.Sprintf("Response file '%s'", fileName),
+		})
 	}
 
-	return nil
+	return files, nil
 }
 
 // ensureDirectoryExists checks if the directory exists, and creates it if it doesn't
@@ -0,0 +1,218 @@
+package commands
+
+import (
+	"fmt"
+	"goi/templates"
+	"goi/utils"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// projectTemplateDir is the project-local directory searched first for template overrides
+const projectTemplateDir = ".goi/templates"
+
+// builtinTemplates maps each overridable template name to its compiled-in content
+var builtinTemplates = map[string]string{
+	"handler":                      templates.HandlerTemplate,
+	"dto":                          templates.DTOTemplate,
+	"model":                        templates.ModelTemplate,
+	"service":                      templates.ServiceTemplate,
+	"repository":                   templates.RepositoryTemplate,
+	"repository_sqlx":              templates.RepositorySQLXTemplate,
+	"repository_pgx":               templates.RepositoryPgxTemplate,
+	"repository_sql":               templates.RepositoryDatabaseSQLTemplate,
+	"model_sql":                    templates.ModelSQLTemplate,
+	"handler_test":                 templates.HandlerTestTemplate,
+	"repository_test":              templates.RepositoryTestTemplate,
+	"repository_test_sql":          templates.RepositoryTestSQLTemplate,
+	"route":                        templates.RouteTemplate,
+	"container":                    templates.ContainerTemplate,
+	"success_response":             templates.SuccessResponseTemplate,
+	"error_response":               templates.ErrorResponseTemplate,
+	"pagination_response":          templates.PaginationResponseTemplate,
+	"migration_up":                 templates.MigrationUpTemplate,
+	"migration_down":               templates.MigrationDownTemplate,
+	"version":                      templates.VersionTemplate,
+	"middleware_blank":             templates.BlankMiddlewareTemplate,
+	"middleware_jwt":               templates.JWTMiddlewareTemplate,
+	"middleware_cors":              templates.CORSMiddlewareTemplate,
+	"middleware_ratelimit":         templates.RateLimitMiddlewareTemplate,
+	"middleware_requestid":         templates.RequestIDMiddlewareTemplate,
+	"middleware_logger":            templates.LoggerMiddlewareTemplate,
+	"middleware_recover":           templates.RecoverMiddlewareTemplate,
+	"handler_echo":                 templates.HandlerEchoTemplate,
+	"handler_test_echo":            templates.HandlerTestEchoTemplate,
+	"route_echo":                   templates.RouteEchoTemplate,
+	"success_response_echo":        templates.SuccessResponseEchoTemplate,
+	"error_response_echo":          templates.ErrorResponseEchoTemplate,
+	"pagination_response_echo":     templates.PaginationResponseEchoTemplate,
+	"middleware_blank_echo":        templates.BlankMiddlewareEchoTemplate,
+	"middleware_jwt_echo":          templates.JWTMiddlewareEchoTemplate,
+	"middleware_cors_echo":         templates.CORSMiddlewareEchoTemplate,
+	"middleware_ratelimit_echo":    templates.RateLimitMiddlewareEchoTemplate,
+	"middleware_requestid_echo":    templates.RequestIDMiddlewareEchoTemplate,
+	"middleware_logger_echo":       templates.LoggerMiddlewareEchoTemplate,
+	"middleware_recover_echo":      templates.RecoverMiddlewareEchoTemplate,
+	"handler_fiber":                templates.HandlerFiberTemplate,
+	"handler_test_fiber":           templates.HandlerTestFiberTemplate,
+	"route_fiber":                  templates.RouteFiberTemplate,
+	"success_response_fiber":       templates.SuccessResponseFiberTemplate,
+	"error_response_fiber":         templates.ErrorResponseFiberTemplate,
+	"pagination_response_fiber":    templates.PaginationResponseFiberTemplate,
+	"middleware_blank_fiber":       templates.BlankMiddlewareFiberTemplate,
+	"middleware_jwt_fiber":         templates.JWTMiddlewareFiberTemplate,
+	"middleware_cors_fiber":        templates.CORSMiddlewareFiberTemplate,
+	"middleware_ratelimit_fiber":   templates.RateLimitMiddlewareFiberTemplate,
+	"middleware_requestid_fiber":   templates.RequestIDMiddlewareFiberTemplate,
+	"middleware_logger_fiber":      templates.LoggerMiddlewareFiberTemplate,
+	"middleware_recover_fiber":     templates.RecoverMiddlewareFiberTemplate,
+	"handler_nethttp":              templates.HandlerNetHTTPTemplate,
+	"handler_test_nethttp":         templates.HandlerTestNetHTTPTemplate,
+	"route_nethttp":                templates.RouteNetHTTPTemplate,
+	"success_response_nethttp":     templates.SuccessResponseNetHTTPTemplate,
+	"error_response_nethttp":       templates.ErrorResponseNetHTTPTemplate,
+	"pagination_response_nethttp":  templates.PaginationResponseNetHTTPTemplate,
+	"middleware_blank_nethttp":     templates.BlankMiddlewareNetHTTPTemplate,
+	"middleware_jwt_nethttp":       templates.JWTMiddlewareNetHTTPTemplate,
+	"middleware_cors_nethttp":      templates.CORSMiddlewareNetHTTPTemplate,
+	"middleware_ratelimit_nethttp": templates.RateLimitMiddlewareNetHTTPTemplate,
+	"middleware_requestid_nethttp": templates.RequestIDMiddlewareNetHTTPTemplate,
+	"middleware_logger_nethttp":    templates.LoggerMiddlewareNetHTTPTemplate,
+	"middleware_recover_nethttp":   templates.RecoverMiddlewareNetHTTPTemplate,
+}
+
+// Flag variables for the template subcommands
+var ejectGlobal bool
+
+// MakeTemplateCmd groups the commands that manage generator templates
+var MakeTemplateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage the templates used by the goi make generators",
+	Long: `The 'template' command manages the templates used by 'goi make'.
+
+Templates are looked up in the following order:
+  1. .goi/templates/<type>.tmpl in the current project
+  2. ~/.config/goi/templates/<type>.tmpl for the current user
+  3. The templates compiled into goi`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("subcommand is required. Example: goi make template eject handler")
+	},
+}
+
+// MakeTemplateEjectCmd copies a built-in template out so it can be edited
+var MakeTemplateEjectCmd = &cobra.Command{
+	Use:   "eject <type>",
+	Short: "Copy a built-in template into .goi/templates so it can be customised",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return ejectTemplate(args[0])
+	},
+}
+
+// MakeTemplateListCmd lists every template and where it is currently loaded from
+var MakeTemplateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available templates and the source each one is loaded from",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, name := range templateNames() {
+			_, source, err := loadTemplate(name)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%-20s %s\n", name, source)
+		}
+		return nil
+	},
+}
+
+func init() {
+	MakeTemplateCmd.AddCommand(MakeTemplateEjectCmd)
+	MakeTemplateCmd.AddCommand(MakeTemplateListCmd)
+
+	MakeTemplateEjectCmd.Flags().BoolVarP(&ejectGlobal, "global", "g", false, "Eject into ~/.config/goi/templates instead of the project")
+}
+
+// templateNames returns the names of all overridable templates in a stable order
+func templateNames() []string {
+	names := make([]string, 0, len(builtinTemplates))
+	for name := range builtinTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// userTemplateDir returns the user-level template directory (~/.config/goi/templates)
+func userTemplateDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "goi", "templates"), nil
+}
+
+// templateSearchPaths returns the override locations for a template, most specific first
+func templateSearchPaths(name string) []string {
+	paths := []string{filepath.Join(projectTemplateDir, name+".tmpl")}
+	if dir, err := userTemplateDir(); err == nil {
+		paths = append(paths, filepath.Join(dir, name+".tmpl"))
+	}
+	return paths
+}
+
+// loadTemplate returns the content of the named template together with the place it was loaded from.
+// Project overrides win over user overrides, which win over the built-in templates.
+func loadTemplate(name string) (string, string, error) {
+	builtin, ok := builtinTemplates[name]
+	if !ok {
+		return "", "", fmt.Errorf("unknown template type: %s", name)
+	}
+
+	for _, path := range templateSearchPaths(name) {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return string(data), path, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", "", fmt.Errorf("failed to read template override %s: %w", path, err)
+		}
+	}
+
+	return builtin, "built-in", nil
+}
+
+// ejectTemplate writes the built-in template of the given type into an override directory
+func ejectTemplate(name string) error {
+	content, ok := builtinTemplates[name]
+	if !ok {
+		return fmt.Errorf("unknown template type: %s (available: %v)", name, templateNames())
+	}
+
+	dir := projectTemplateDir
+	if ejectGlobal {
+		userDir, err := userTemplateDir()
+		if err != nil {
+			return err
+		}
+		dir = userDir
+	}
+
+	if err := ensureDirectoryExists(dir); err != nil {
+		return err
+	}
+
+	target := filepath.Join(dir, name+".tmpl")
+	if fileExists(target) && !makeForce {
+		return fmt.Errorf("template override %s already exists, use --force to overwrite it", target)
+	}
+
+	if err := os.WriteFile(target, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write template %s: %w", target, err)
+	}
+
+	utils.PrintSuccess(fmt.Sprintf("Template '%s' ejected to %s", name, target))
+	return nil
+}
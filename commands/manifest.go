@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"goi/utils"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// manifestPath records what 'goi make' generated so 'goi destroy' can remove exactly that
+const manifestPath = ".goi/manifest.json"
+
+// generationManifest maps each generated resource to the files it produced
+type generationManifest struct {
+	Resources map[string]*manifestEntry `json:"resources"`
+}
+
+// manifestEntry lists the files generated for one resource and the project files it injected code into
+type manifestEntry struct {
+	Files map[string]string `json:"files"`           // Generated file path -> sha256 of its content
+	Edits []string          `json:"edits,omitempty"` // Files edited in place, e.g. the router setup and wiring files
+}
+
+// loadManifest reads the generation manifest, returning an empty one if it does not exist yet
+func loadManifest() (*generationManifest, error) {
+	manifest := &generationManifest{Resources: map[string]*manifestEntry{}}
+	data, err := os.ReadFile(manifestPath)
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", manifestPath, err)
+	}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", manifestPath, err)
+	}
+	if manifest.Resources == nil {
+		manifest.Resources = map[string]*manifestEntry{}
+	}
+	return manifest, nil
+}
+
+// save writes the manifest, removing the file once no resources are left
+func (m *generationManifest) save() error {
+	if len(m.Resources) == 0 {
+		if err := os.Remove(manifestPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", manifestPath, err)
+		}
+		removeEmptyDir(filepath.Dir(manifestPath))
+		return nil
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", manifestPath, err)
+	}
+	if err := ensureDirectoryExists(filepath.Dir(manifestPath)); err != nil {
+		return err
+	}
+	if err := os.WriteFile(manifestPath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", manifestPath, err)
+	}
+	return nil
+}
+
+// writeResourceFiles writes the files generated for a resource and records them in the manifest
+func writeResourceFiles(resourceName string, files []generatedFile) error {
+	if err := writeGeneratedFiles(files); err != nil {
+		return err
+	}
+	if makeDryRun || makeDiff {
+		return nil
+	}
+	if err := recordGeneration(resourceName, files); err != nil {
+		utils.PrintWarning(fmt.Sprintf("Generated files could not be recorded, 'goi destroy' will not know about them: %v", err))
+	}
+	return nil
+}
+
+// recordGeneration adds the files to the manifest entry of the resource
+func recordGeneration(resourceName string, files []generatedFile) error {
+	manifest, err := loadManifest()
+	if err != nil {
+		return err
+	}
+
+	key := manifestKey(resourceName)
+	entry, ok := manifest.Resources[key]
+	if !ok {
+		entry = &manifestEntry{Files: map[string]string{}}
+		manifest.Resources[key] = entry
+	}
+
+	edits := map[string]bool{}
+	for _, path := range entry.Edits {
+		edits[path] = true
+	}
+	for _, file := range files {
+		path := filepath.ToSlash(file.Path)
+		if file.Modify {
+			edits[path] = true
+			continue
+		}
+		entry.Files[path] = contentHash(file.Content)
+	}
+
+	entry.Edits = entry.Edits[:0]
+	for path := range edits {
+		entry.Edits = append(entry.Edits, path)
+	}
+	sort.Strings(entry.Edits)
+
+	return manifest.save()
+}
+
+// manifestKey normalises a resource name the same way the generators do, e.g. "order_items" -> "OrderItem"
+func manifestKey(resourceName string) string {
+	return utils.Pascal(utils.Singular(resourceName))
+}
+
+// contentHash returns the hex encoded sha256 of a file's content
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
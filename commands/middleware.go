@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"goi/utils"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// middlewareKinds maps each --kind value to the template it renders
+var middlewareKinds = map[string]string{
+	"blank":     "middleware_blank",
+	"jwt":       "middleware_jwt",
+	"cors":      "middleware_cors",
+	"ratelimit": "middleware_ratelimit",
+	"requestid": "middleware_requestid",
+	"logger":    "middleware_logger",
+	"recover":   "middleware_recover",
+}
+
+// middlewareKindAliases lets the kind be inferred from common middleware names when --kind is omitted
+var middlewareKindAliases = map[string]string{
+	"auth":       "jwt",
+	"jwt":        "jwt",
+	"cors":       "cors",
+	"ratelimit":  "ratelimit",
+	"rate_limit": "ratelimit",
+	"limiter":    "ratelimit",
+	"requestid":  "requestid",
+	"request_id": "requestid",
+	"logger":     "logger",
+	"logging":    "logger",
+	"recover":    "recover",
+	"recovery":   "recover",
+}
+
+// makeMiddlewareKind selects the middleware variant to generate
+var makeMiddlewareKind string
+
+// MakeMiddlewareCmd generates a middleware for the project's HTTP framework in the middleware package
+var MakeMiddlewareCmd = &cobra.Command{
+	Use:   "middleware <name>",
+	Short: "Generate a new middleware (jwt, cors, ratelimit, requestid, logger, recover)",
+	Long: `The 'middleware' command generates a middleware in middleware/<name>_middleware.go
+(or the layout.middleware directory of goi.yaml), written for the project's HTTP
+framework (see --http).
+
+Kinds:
+  jwt        Verifies RS256 bearer tokens with config/rsa_public.pem (see 'goi keys')
+  cors       Adds CORS headers and answers preflight requests
+  ratelimit  Per-client token bucket rate limiting
+  requestid  Assigns an X-Request-ID to every request
+  logger     Structured request logging with log/slog
+  recover    Turns panics into 500 responses
+  blank      An empty middleware skeleton
+
+When --kind is omitted it is inferred from the name (auth, cors, logging, ...),
+falling back to blank.`,
+	Example: `  goi make middleware auth --kind jwt
+  goi make middleware cors
+  goi make middleware api_limiter --kind ratelimit`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		moduleName, err := getModuleNameFromGoMod(".")
+		if err != nil {
+			return fmt.Errorf("failed to get module name from go.mod: %w", err)
+		}
+
+		file, err := renderMiddlewareFile(args[0], makeMiddlewareKind, moduleName)
+		if err != nil {
+			return err
+		}
+		return writeGeneratedFiles([]generatedFile{file})
+	},
+}
+
+func init() {
+	MakeMiddlewareCmd.Flags().StringVarP(&makeMiddlewareKind, "kind", "k", "", "Middleware variant: "+strings.Join(middlewareKindNames(), ", "))
+}
+
+// renderMiddlewareFile renders the middleware of the given kind without touching the disk
+func renderMiddlewareFile(name, kind, moduleName string) (generatedFile, error) {
+	// "auth_middleware" and "AuthMiddleware" both name the Auth middleware
+	base := utils.Snake(name)
+	base = strings.TrimSuffix(strings.TrimSuffix(base, "_middleware"), "middleware")
+	if base == "" {
+		return generatedFile{}, fmt.Errorf("invalid middleware name %q", name)
+	}
+
+	if kind == "" {
+		kind = middlewareKindAliases[base]
+		if kind == "" {
+			kind = "blank"
+		}
+	}
+	templateName, ok := middlewareKinds[strings.ToLower(kind)]
+	if !ok {
+		return generatedFile{}, fmt.Errorf("unknown middleware kind %q, expected one of: %s", kind, strings.Join(middlewareKindNames(), ", "))
+	}
+
+	framework, err := selectedHTTPFramework()
+	if err != nil {
+		return generatedFile{}, err
+	}
+	tmpl, err := parseTemplateForResource(httpTemplateName(templateName, framework))
+	if err != nil {
+		return generatedFile{}, err
+	}
+
+	pascal := utils.Pascal(base)
+	data := map[string]interface{}{
+		"Name":       pascal,              // Auth
+		"Var":        utils.VarName(base), // auth
+		"Snake":      base,                // auth
+		"Kind":       kind,
+		"ModuleName": moduleName,
+		"HTTP":       framework,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return generatedFile{}, fmt.Errorf("failed to render %s middleware template: %w", kind, err)
+	}
+
+	path := filepath.Join(projectLayout().Middleware, base+"_middleware.go")
+	content, err := formatGoSource(path, buf.Bytes())
+	if err != nil {
+		return generatedFile{}, err
+	}
+	return generatedFile{
+		Path:    path,
+		Content: content,
+		Label:   fmt.Sprintf("Middleware '%sMiddleware'", pascal),
+	}, nil
+}
+
+// middlewareKindNames returns the supported --kind values in a stable order
+func middlewareKindNames() []string {
+	names := make([]string, 0, len(middlewareKinds))
+	for kind := range middlewareKinds {
+		names = append(names, kind)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,453 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"goi/utils"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// schemaMigrationsTable records the versions that have been applied
+const schemaMigrationsTable = "schema_migrations"
+
+// Flag variables for the migrate command
+var migrateDir string
+var migrateEnvFile string
+var migrateUpSteps int
+var migrateDownSteps int
+
+// SQL dialects of the generated migrations and of 'goi migrate'
+const (
+	dialectMySQL    = "mysql"
+	dialectPostgres = "postgres"
+)
+
+// dialectAliases are accepted spellings of the database drivers
+var dialectAliases = map[string]string{
+	"mysql":      dialectMySQL,
+	"mariadb":    dialectMySQL,
+	"postgres":   dialectPostgres,
+	"postgresql": dialectPostgres,
+	"pgx":        dialectPostgres,
+}
+
+// defaultPostgresPort replaces the MySQL default port of goi.yaml for PostgreSQL databases
+const defaultPostgresPort = "5432"
+
+// databaseConfig holds the connection settings read from the environment or goi.yaml
+type databaseConfig struct {
+	Driver   string `yaml:"driver,omitempty"` // mysql or postgres, see selectedDialect
+	Host     string `yaml:"host,omitempty"`
+	Port     string `yaml:"port,omitempty"`
+	User     string `yaml:"user,omitempty"`
+	Password string `yaml:"password,omitempty"`
+	Name     string `yaml:"name,omitempty"`
+}
+
+// MigrateCmd groups the commands that apply SQL migrations
+var MigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply or roll back the SQL migrations in migrations/",
+	Long: `The 'migrate' command applies the SQL migrations created by 'goi make migration'
+to the database configured in the project's .env file (DB_DRIVER, DB_HOST,
+DB_PORT, DB_USER, DB_PASSWORD, DB_NAME). Variables already set in the environment
+take precedence over the file, and the database section of goi.yaml fills in what
+neither sets.
+
+The database is MySQL unless DB_DRIVER or database.driver is postgres, or the
+ORM is pgx. Applied versions are recorded in the schema_migrations table. The
+mysql or psql client must be installed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("subcommand is required. Example: goi migrate up")
+	},
+}
+
+// MigrateUpCmd applies pending migrations
+var MigrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply pending migrations (all of them unless --steps is given)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, migrations, applied, err := prepareMigrate()
+		if err != nil {
+			return err
+		}
+		return migrateUp(db, migrations, applied, migrateUpSteps)
+	},
+}
+
+// MigrateDownCmd rolls back the latest applied migrations
+var MigrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Roll back the latest applied migration (or --steps migrations)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, migrations, applied, err := prepareMigrate()
+		if err != nil {
+			return err
+		}
+		return migrateDown(db, migrations, applied, migrateDownSteps)
+	},
+}
+
+// MigrateRedoCmd rolls back the latest migration and applies it again
+var MigrateRedoCmd = &cobra.Command{
+	Use:   "redo",
+	Short: "Roll back the latest applied migration and apply it again",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, migrations, applied, err := prepareMigrate()
+		if err != nil {
+			return err
+		}
+		if err := migrateDown(db, migrations, applied, 1); err != nil {
+			return err
+		}
+
+		applied, err = appliedMigrations(db)
+		if err != nil {
+			return err
+		}
+		return migrateUp(db, migrations, applied, 1)
+	},
+}
+
+// MigrateStatusCmd lists every migration and whether it has been applied
+var MigrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which migrations have been applied",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, migrations, applied, err := prepareMigrate()
+		if err != nil {
+			return err
+		}
+
+		utils.PrintInfo(fmt.Sprintf("Database '%s' on %s:%s", db.Name, db.Host, db.Port))
+		known := map[string]bool{}
+		pending := 0
+		for _, m := range migrations {
+			known[m.Version] = true
+			status := "applied"
+			if !applied[m.Version] {
+				status = "pending"
+				pending++
+			}
+			fmt.Printf("%-8s %s_%s\n", status, m.Version, m.Name)
+		}
+		var missing []string
+		for version := range applied {
+			if !known[version] {
+				missing = append(missing, version)
+			}
+		}
+		sort.Strings(missing)
+		for _, version := range missing {
+			fmt.Printf("%-8s %s (no migration file)\n", "missing", version)
+		}
+
+		if pending == 0 {
+			utils.PrintSuccess("Database is up to date")
+		} else {
+			utils.PrintWarning(fmt.Sprintf("%d pending migration(s), run 'goi migrate up' to apply them", pending))
+		}
+		return nil
+	},
+}
+
+func init() {
+	MigrateCmd.AddCommand(MigrateUpCmd)
+	MigrateCmd.AddCommand(MigrateDownCmd)
+	MigrateCmd.AddCommand(MigrateRedoCmd)
+	MigrateCmd.AddCommand(MigrateStatusCmd)
+
+	MigrateCmd.PersistentFlags().StringVar(&migrateDir, "dir", "", "Directory containing the migration files (defaults to layout.migrations of goi.yaml, then "+defaultMigrationsDir+")")
+	MigrateCmd.PersistentFlags().StringVar(&migrateEnvFile, "env-file", ".env", "File to read the database configuration from")
+	MigrateUpCmd.Flags().IntVarP(&migrateUpSteps, "steps", "n", 0, "Number of pending migrations to apply (0 applies all)")
+	MigrateDownCmd.Flags().IntVarP(&migrateDownSteps, "steps", "n", 1, "Number of applied migrations to roll back")
+}
+
+// prepareMigrate loads the database configuration, the migration files and the applied versions
+func prepareMigrate() (databaseConfig, []migration, map[string]bool, error) {
+	if err := loadEnvFile(migrateEnvFile); err != nil {
+		return databaseConfig{}, nil, nil, err
+	}
+	db, err := loadDatabaseConfig(migrateEnvFile)
+	if err != nil {
+		return db, nil, nil, err
+	}
+
+	dir := migrateDir
+	if dir == "" {
+		dir = projectLayout().Migrations
+	}
+	migrations, err := listMigrations(dir)
+	if err != nil {
+		return db, nil, nil, err
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return db, nil, nil, err
+	}
+	return db, migrations, applied, nil
+}
+
+// migrateUp applies up to steps pending migrations in version order; steps <= 0 applies all of them
+func migrateUp(db databaseConfig, migrations []migration, applied map[string]bool, steps int) error {
+	count := 0
+	for _, m := range migrations {
+		if applied[m.Version] {
+			continue
+		}
+		if steps > 0 && count == steps {
+			break
+		}
+
+		sql, err := os.ReadFile(m.UpPath)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", m.UpPath, err)
+		}
+		record := fmt.Sprintf("INSERT INTO %s (version) VALUES ('%s');", schemaMigrationsTable, m.Version)
+		if err := runSQL(db, migrationScript(sql, record), nil); err != nil {
+			return fmt.Errorf("failed to apply migration %s_%s: %w", m.Version, m.Name, err)
+		}
+		utils.PrintSuccess(fmt.Sprintf("Applied %s_%s", m.Version, m.Name))
+		count++
+	}
+
+	if count == 0 {
+		utils.PrintInfo("No pending migrations")
+	}
+	return nil
+}
+
+// migrateDown rolls back the latest steps applied migrations in reverse version order
+func migrateDown(db databaseConfig, migrations []migration, applied map[string]bool, steps int) error {
+	count := 0
+	for i := len(migrations) - 1; i >= 0 && count < steps; i-- {
+		m := migrations[i]
+		if !applied[m.Version] {
+			continue
+		}
+		if m.DownPath == "" {
+			return fmt.Errorf("migration %s_%s has no .down.sql file and cannot be rolled back", m.Version, m.Name)
+		}
+
+		sql, err := os.ReadFile(m.DownPath)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", m.DownPath, err)
+		}
+		record := fmt.Sprintf("DELETE FROM %s WHERE version = '%s';", schemaMigrationsTable, m.Version)
+		if err := runSQL(db, migrationScript(sql, record), nil); err != nil {
+			return fmt.Errorf("failed to roll back migration %s_%s: %w", m.Version, m.Name, err)
+		}
+		utils.PrintSuccess(fmt.Sprintf("Rolled back %s_%s", m.Version, m.Name))
+		count++
+	}
+
+	if count == 0 {
+		utils.PrintInfo("No applied migrations to roll back")
+	}
+	return nil
+}
+
+// migrationScript appends the schema_migrations bookkeeping statement to a migration's SQL
+func migrationScript(sql []byte, record string) []byte {
+	var script bytes.Buffer
+	script.Write(bytes.TrimRight(sql, " \t\r\n"))
+	if script.Len() > 0 && !bytes.HasSuffix(script.Bytes(), []byte(";")) {
+		script.WriteString(";")
+	}
+	script.WriteString("\n" + record + "\n")
+	return script.Bytes()
+}
+
+// appliedMigrations creates the schema_migrations table if needed and returns the applied versions
+func appliedMigrations(db databaseConfig) (map[string]bool, error) {
+	appliedAt := "DATETIME"
+	if db.Driver == dialectPostgres {
+		appliedAt = "TIMESTAMP"
+	}
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+  version VARCHAR(255) NOT NULL PRIMARY KEY,
+  applied_at %s NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+SELECT version FROM %s ORDER BY version;`, schemaMigrationsTable, appliedAt, schemaMigrationsTable)
+
+	var output bytes.Buffer
+	if err := runSQL(db, []byte(query), &output); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", schemaMigrationsTable, err)
+	}
+
+	applied := map[string]bool{}
+	for _, line := range strings.Split(output.String(), "\n") {
+		if version := strings.TrimSpace(line); version != "" {
+			applied[version] = true
+		}
+	}
+	return applied, nil
+}
+
+// runSQL feeds a script to the client of the database's dialect. With an output buffer only the
+// rows of the queries are written to it, one per line.
+func runSQL(db databaseConfig, script []byte, output *bytes.Buffer) error {
+	if db.Driver == dialectPostgres {
+		return runPsql(db, script, output)
+	}
+	if output != nil {
+		return runMySQL(db, script, output, "--batch", "--skip-column-names")
+	}
+	return runMySQL(db, script, nil)
+}
+
+// runPsql feeds a script to the psql client in a single transaction, stopping at the first error
+func runPsql(db databaseConfig, script []byte, output *bytes.Buffer) error {
+	args := []string{"-h", db.Host, "-p", db.Port, "-U", db.User, "-d", db.Name, "-v", "ON_ERROR_STOP=1", "--single-transaction", "-q"}
+	if output != nil {
+		args = append(args, "--tuples-only", "--no-align")
+	}
+
+	psqlCmd := exec.Command("psql", args...)
+	// Passing the password through the environment keeps it out of the process list
+	psqlCmd.Env = append(os.Environ(), "PGPASSWORD="+db.Password)
+	psqlCmd.Stdin = bytes.NewReader(script)
+	var stderr bytes.Buffer
+	psqlCmd.Stderr = &stderr
+	if output != nil {
+		psqlCmd.Stdout = output
+	} else {
+		psqlCmd.Stdout = os.Stdout
+	}
+
+	if err := psqlCmd.Run(); err != nil {
+		if _, lookErr := exec.LookPath("psql"); lookErr != nil {
+			return fmt.Errorf("the psql client is not installed: %w", lookErr)
+		}
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// runMySQL feeds a script to the mysql client; output goes to stdout unless a buffer is given
+func runMySQL(db databaseConfig, script []byte, output *bytes.Buffer, extraArgs ...string) error {
+	args := []string{"-h", db.Host, "-P", db.Port, "-u", db.User}
+	args = append(args, extraArgs...)
+	args = append(args, db.Name)
+
+	mysqlCmd := exec.Command("mysql", args...)
+	// Passing the password through the environment keeps it out of the process list
+	mysqlCmd.Env = append(os.Environ(), "MYSQL_PWD="+db.Password)
+	mysqlCmd.Stdin = bytes.NewReader(script)
+	var stderr bytes.Buffer
+	mysqlCmd.Stderr = &stderr
+	if output != nil {
+		mysqlCmd.Stdout = output
+	} else {
+		mysqlCmd.Stdout = os.Stdout
+	}
+
+	if err := mysqlCmd.Run(); err != nil {
+		if _, lookErr := exec.LookPath("mysql"); lookErr != nil {
+			return fmt.Errorf("the mysql client is not installed: %w", lookErr)
+		}
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// loadDatabaseConfig reads the database settings from the environment, falling back to the .env file
+// and then to the database section of goi.yaml, and checks that the user and database name are set
+func loadDatabaseConfig(envFile string) (databaseConfig, error) {
+	db, err := readDatabaseConfig(envFile)
+	if err != nil {
+		return db, err
+	}
+	if db.User == "" || db.Name == "" {
+		return db, fmt.Errorf("database configuration is incomplete: set DB_USER and DB_NAME in %s or the environment, or database.user and database.name in %s", envFile, projectConfigFile)
+	}
+	return db, nil
+}
+
+// readDatabaseConfig reads the database settings like loadDatabaseConfig, leaving settings that are not given empty
+func readDatabaseConfig(envFile string) (databaseConfig, error) {
+	values, err := utils.ReadEnvFile(envFile)
+	if err != nil && !os.IsNotExist(err) {
+		return databaseConfig{}, fmt.Errorf("failed to read %s: %w", envFile, err)
+	}
+	config, err := loadProjectConfig()
+	if err != nil {
+		return databaseConfig{}, err
+	}
+
+	lookup := func(fallback string, keys ...string) string {
+		for _, key := range keys {
+			if value, ok := os.LookupEnv(key); ok && value != "" {
+				return value
+			}
+			if value := values[key]; value != "" {
+				return value
+			}
+		}
+		return fallback
+	}
+
+	dialect, err := selectedDialect()
+	if err != nil {
+		return databaseConfig{}, err
+	}
+	// The default port of goi.yaml is MySQL's
+	port := config.Database.Port
+	if dialect == dialectPostgres && port == defaultProjectConfig().Database.Port {
+		port = defaultPostgresPort
+	}
+
+	db := databaseConfig{
+		Driver:   dialect,
+		Host:     lookup(config.Database.Host, "DB_HOST"),
+		Port:     lookup(port, "DB_PORT"),
+		User:     lookup(config.Database.User, "DB_USER", "DB_USERNAME"),
+		Password: lookup(config.Database.Password, "DB_PASSWORD", "DB_PASS"),
+		Name:     lookup(config.Database.Name, "DB_NAME", "DB_DATABASE"),
+	}
+	return db, nil
+}
+
+// selectedDialect returns the SQL dialect of the project's database: DB_DRIVER of the environment or .env,
+// then database.driver of goi.yaml, then postgres when the ORM is pgx and mysql otherwise
+func selectedDialect() (string, error) {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return "", err
+	}
+	driver := os.Getenv("DB_DRIVER")
+	if driver == "" {
+		if values, err := utils.ReadEnvFile(defaultEnvFile); err == nil {
+			driver = values["DB_DRIVER"]
+		}
+	}
+	if driver == "" {
+		driver = config.Database.Driver
+	}
+	if driver == "" {
+		backend, err := selectedORM()
+		if err != nil {
+			return "", err
+		}
+		if backend.Name == "pgx" {
+			return dialectPostgres, nil
+		}
+		return dialectMySQL, nil
+	}
+
+	dialect, ok := dialectAliases[strings.ToLower(driver)]
+	if !ok {
+		return "", fmt.Errorf("unknown database driver %q, expected mysql or postgres", driver)
+	}
+	return dialect, nil
+}
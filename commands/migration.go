@@ -0,0 +1,236 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"goi/utils"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultMigrationsDir holds the SQL migrations created by 'goi make migration' and applied by 'goi migrate'
+// unless goi.yaml sets layout.migrations
+const defaultMigrationsDir = "migrations"
+
+// migrationVersionFormat is the UTC timestamp that prefixes every migration file
+const migrationVersionFormat = "20060102150405"
+
+// migrationFilePattern matches <version>_<name>.up.sql and <version>_<name>.down.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Flag variables for the migration generator
+var makeMigrationTable string
+var makeMigration bool
+
+// migration is a pair of up/down SQL files sharing a version
+type migration struct {
+	Version  string
+	Name     string
+	UpPath   string
+	DownPath string
+}
+
+// MakeMigrationCmd generates a pair of timestamped up/down SQL migration files
+var MakeMigrationCmd = &cobra.Command{
+	Use:   "migration <name>",
+	Short: "Generate timestamped up/down SQL migration files in migrations/",
+	Long: `The 'migration' command creates migrations/<timestamp>_<name>.up.sql and
+migrations/<timestamp>_<name>.down.sql. Apply them with 'goi migrate up'.
+
+With --fields the up migration creates the table that 'goi make model' maps the
+same fields to, and the down migration drops it. The table is taken from --table
+or from the migration name, e.g. create_order_items_table -> order_items.
+
+The SQL is written for MySQL, or for PostgreSQL when DB_DRIVER or database.driver
+of goi.yaml is postgres, or the ORM is pgx.`,
+	Example: `  goi make migration add_index_to_products
+  goi make migration create_products_table --fields "name:string:required,price:float64,sku:string:unique"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fields, err := loadFields()
+		if err != nil {
+			return err
+		}
+
+		table := makeMigrationTable
+		if table == "" {
+			table = migrationTableFromName(args[0])
+		}
+		if len(fields) > 0 && table == "" {
+			return fmt.Errorf("cannot tell which table %q creates, name it create_<table>_table or pass --table", args[0])
+		}
+
+		files, err := renderMigrationFiles(args[0], table, fields, time.Now())
+		if err != nil {
+			return err
+		}
+		return writeGeneratedFiles(files)
+	},
+}
+
+func init() {
+	MakeMigrationCmd.Flags().StringVar(&makeMigrationTable, "table", "", "Table created by the migration when --fields is given")
+}
+
+// renderMigrationFiles renders the up and down migration files without touching the disk
+func renderMigrationFiles(name, table string, fields []Field, now time.Time) ([]generatedFile, error) {
+	snake := utils.Snake(name)
+	if snake == "" {
+		return nil, fmt.Errorf("invalid migration name %q", name)
+	}
+	dir := projectLayout().Migrations
+	version, err := nextMigrationVersion(dir, now)
+	if err != nil {
+		return nil, err
+	}
+
+	dialect, err := selectedDialect()
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"Name":    snake,
+		"Version": version,
+		"Table":   table,
+		"Fields":  fields,
+		"Dialect": dialect,
+	}
+
+	var files []generatedFile
+	for _, direction := range []string{"up", "down"} {
+		tmpl, err := parseTemplateForResource("migration_" + direction)
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s migration template: %w", direction, err)
+		}
+
+		files = append(files, generatedFile{
+			Path:    filepath.Join(dir, fmt.Sprintf("%s_%s.%s.sql", version, snake, direction)),
+			Content: buf.Bytes(),
+			Label:   fmt.Sprintf("Migration '%s' (%s)", snake, direction),
+		})
+	}
+	return files, nil
+}
+
+// nextMigrationVersion returns the timestamp version for a new migration.
+// It is bumped past the latest existing version so migrations generated within the same second stay ordered.
+func nextMigrationVersion(dir string, now time.Time) (string, error) {
+	version := now.UTC().Format(migrationVersionFormat)
+
+	migrations, err := listMigrations(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(migrations) == 0 {
+		return version, nil
+	}
+
+	latest := migrations[len(migrations)-1].Version
+	if len(latest) < len(version) || (len(latest) == len(version) && latest < version) {
+		return version, nil
+	}
+	n, err := strconv.ParseUint(latest, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid migration version %s: %w", latest, err)
+	}
+	return strconv.FormatUint(n+1, 10), nil
+}
+
+// renderCreateTableMigration renders the create_<table>_table migration of a model, or nothing if one already exists
+func renderCreateTableMigration(resourceName string, fields []Field) ([]generatedFile, error) {
+	table := utils.TableName(resourceName)
+	name := "create_" + table + "_table"
+
+	migrations, err := listMigrations(projectLayout().Migrations)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range migrations {
+		if m.Name == name {
+			utils.PrintInfo(fmt.Sprintf("Migration '%s' already exists (%s)", name, m.UpPath))
+			return nil, nil
+		}
+	}
+	return renderMigrationFiles(name, table, fields, time.Now())
+}
+
+// migrationTableFromName derives the table from names such as create_users_table or add_users_table
+func migrationTableFromName(name string) string {
+	words := utils.SplitWords(name)
+	if len(words) < 2 || (words[0] != "create" && words[0] != "add") {
+		return ""
+	}
+	words = words[1:]
+	if words[len(words)-1] == "table" {
+		words = words[:len(words)-1]
+	}
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.Join(words, "_")
+}
+
+// listMigrations returns the migrations in dir ordered by version; a missing directory has no migrations
+func listMigrations(dir string) ([]migration, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory %s: %w", dir, err)
+	}
+
+	byVersion := map[string]*migration{}
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, name, direction := match[1], match[2], match[3]
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migrations %s_%s and %s_%s share version %s", version, m.Name, version, name, version)
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		if direction == "up" {
+			m.UpPath = path
+		} else {
+			m.DownPath = path
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.UpPath == "" {
+			return nil, fmt.Errorf("migration %s_%s has no .up.sql file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	// Versions are numeric, so a shorter version sorts first
+	sort.Slice(migrations, func(i, j int) bool {
+		a, b := migrations[i].Version, migrations[j].Version
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return a < b
+	})
+	return migrations, nil
+}
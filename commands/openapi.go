@@ -0,0 +1,712 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"goi/utils"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Flag variables for the openapi command
+var openAPIOutput string
+var openAPITitle string
+var openAPIVersion string
+var openAPIServers []string
+var openAPICheck bool
+
+// openAPIDocument is the root of the generated OpenAPI 3.0 document
+type openAPIDocument struct {
+	OpenAPI    string                                  `yaml:"openapi"`
+	Info       openAPIInfo                             `yaml:"info"`
+	Servers    []openAPIServer                         `yaml:"servers,omitempty"`
+	Paths      map[string]map[string]*openAPIOperation `yaml:"paths"`
+	Components openAPIComponents                       `yaml:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description,omitempty"`
+	Version     string `yaml:"version"`
+}
+
+type openAPIServer struct {
+	URL string `yaml:"url"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*openAPISchema `yaml:"schemas,omitempty"`
+}
+
+type openAPIOperation struct {
+	Tags        []string                    `yaml:"tags,omitempty"`
+	Summary     string                      `yaml:"summary,omitempty"`
+	OperationID string                      `yaml:"operationId"`
+	Parameters  []openAPIParameter          `yaml:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `yaml:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `yaml:"responses"`
+}
+
+type openAPIParameter struct {
+	Name     string         `yaml:"name"`
+	In       string         `yaml:"in"`
+	Required bool           `yaml:"required,omitempty"`
+	Schema   *openAPISchema `yaml:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `yaml:"required"`
+	Content  map[string]openAPIMediaType `yaml:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `yaml:"description"`
+	Content     map[string]openAPIMediaType `yaml:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `yaml:"schema"`
+}
+
+// routeRegistration is one route found in the project's router setup code
+type routeRegistration struct {
+	Method      string // GET, POST, ...
+	Path        string // Gin path, e.g. /products/:id
+	HandlerType string // e.g. ProductHandler, empty when the handler could not be resolved
+	HandlerFunc string // e.g. Show
+}
+
+// handlerResponse is a status code a handler method can respond with, and the body it sends
+type handlerResponse struct {
+	Status int
+	Schema *openAPISchema
+}
+
+// handlerInfo describes what a handler method reads and writes
+type handlerInfo struct {
+	Doc         string
+	RequestBody *openAPISchema
+	Query       []string
+	Responses   []handlerResponse
+}
+
+// httpStatusConstants maps the net/http status constants used by handlers to their codes
+var httpStatusConstants = map[string]int{
+	"StatusOK": 200, "StatusCreated": 201, "StatusAccepted": 202, "StatusNoContent": 204,
+	"StatusMovedPermanently": 301, "StatusFound": 302, "StatusNotModified": 304,
+	"StatusBadRequest": 400, "StatusUnauthorized": 401, "StatusForbidden": 403, "StatusNotFound": 404,
+	"StatusMethodNotAllowed": 405, "StatusConflict": 409, "StatusGone": 410,
+	"StatusUnprocessableEntity": 422, "StatusTooManyRequests": 429,
+	"StatusInternalServerError": 500, "StatusNotImplemented": 501, "StatusBadGateway": 502,
+	"StatusServiceUnavailable": 503, "StatusGatewayTimeout": 504,
+}
+
+// responseHelpers maps the helpers generated by 'goi make response' to the status and envelope they send
+var responseHelpers = map[string]struct {
+	Status   int
+	Envelope string
+}{
+	"Success":          {http.StatusOK, "SuccessResponse"},
+	"SendNoContent":    {http.StatusNoContent, ""},
+	"Paginated":        {http.StatusOK, "Pagination"},
+	"BadRequest":       {http.StatusBadRequest, "ErrorResponse"},
+	"Unauthorized":     {http.StatusUnauthorized, "ErrorResponse"},
+	"NotFoundResponse": {http.StatusNotFound, "ErrorResponse"},
+	"ValidationError":  {http.StatusUnprocessableEntity, "ErrorResponse"},
+	"InternalError":    {http.StatusInternalServerError, "ErrorResponse"},
+}
+
+// routeMethods are the gin router methods that register a route
+var routeMethods = map[string]bool{
+	"GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "HEAD": true, "OPTIONS": true,
+}
+
+// OpenAPICmd groups the OpenAPI commands
+var OpenAPICmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Generate an OpenAPI specification from the project's code",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("subcommand is required. Example: goi openapi generate")
+	},
+}
+
+// OpenAPIGenerateCmd writes openapi.yaml from the routes, handlers and DTOs of the project
+var OpenAPIGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate openapi.yaml from the routes, handlers and DTOs",
+	Long: `The 'generate' command reads the project's source code and writes an OpenAPI 3.0
+specification:
+
+  - paths come from the routes registered on gin routers and groups
+  - request bodies come from the DTOs handlers bind, with constraints taken from
+    their binding tags (required, min, max, oneof, email, ...)
+  - responses come from the status codes and bodies each handler sends, including
+    the SuccessResponse, ErrorResponse and Pagination envelopes of the response package
+
+Run it again whenever the code changes; with --check it fails instead of writing
+when the specification is out of date, which keeps it from drifting in CI.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		content, err := generateOpenAPI(".")
+		if err != nil {
+			return err
+		}
+
+		existing, err := os.ReadFile(openAPIOutput)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", openAPIOutput, err)
+		}
+		if bytes.Equal(existing, content) {
+			utils.PrintInfo(fmt.Sprintf("%s is up to date", openAPIOutput))
+			return nil
+		}
+		if openAPICheck {
+			return fmt.Errorf("%s is out of date, run 'goi openapi generate' to update it", openAPIOutput)
+		}
+
+		if err := ensureDirectoryExists(filepath.Dir(openAPIOutput)); err != nil {
+			return err
+		}
+		if err := os.WriteFile(openAPIOutput, content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", openAPIOutput, err)
+		}
+		utils.PrintSuccess(fmt.Sprintf("OpenAPI specification written to %s", openAPIOutput))
+		return nil
+	},
+}
+
+func init() {
+	OpenAPICmd.AddCommand(OpenAPIGenerateCmd)
+
+	OpenAPIGenerateCmd.Flags().StringVarP(&openAPIOutput, "output", "o", "openapi.yaml", "File to write the specification to")
+	OpenAPIGenerateCmd.Flags().StringVar(&openAPITitle, "title", "", "API title (defaults to the module name)")
+	OpenAPIGenerateCmd.Flags().StringVar(&openAPIVersion, "api-version", "1.0.0", "API version written to info.version")
+	OpenAPIGenerateCmd.Flags().StringArrayVar(&openAPIServers, "server", nil, "Server URL to list in the specification (repeatable)")
+	OpenAPIGenerateCmd.Flags().BoolVar(&openAPICheck, "check", false, "Fail if the specification on disk is out of date instead of writing it")
+}
+
+// generateOpenAPI builds the OpenAPI document of the project rooted at root and encodes it as YAML
+func generateOpenAPI(root string) ([]byte, error) {
+	moduleName, err := getModuleNameFromGoMod(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get module name from go.mod: %w", err)
+	}
+
+	registry, err := loadSchemaRegistry(root)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := findRoutes(root)
+	if err != nil {
+		return nil, err
+	}
+	if len(routes) == 0 {
+		return nil, fmt.Errorf("no gin routes found, register routes with 'goi make resource' first")
+	}
+	handlers, err := analyseHandlers(root, registry)
+	if err != nil {
+		return nil, err
+	}
+
+	title := openAPITitle
+	if title == "" {
+		title = path.Base(moduleName)
+	}
+	doc := openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info: openAPIInfo{
+			Title:       title,
+			Description: "Generated by 'goi openapi generate' from the project's routes, handlers and DTOs.",
+			Version:     openAPIVersion,
+		},
+		Paths: map[string]map[string]*openAPIOperation{},
+	}
+	for _, server := range openAPIServers {
+		doc.Servers = append(doc.Servers, openAPIServer{URL: server})
+	}
+
+	operationIDs := map[string]int{}
+	for _, route := range routes {
+		openAPIPath, params := openAPIPathFromGin(route.Path)
+		operation := &openAPIOperation{
+			Parameters: params,
+			Responses:  map[string]*openAPIResponse{},
+		}
+
+		resource := strings.TrimSuffix(route.HandlerType, "Handler")
+		if resource != "" {
+			operation.Tags = []string{resource}
+		} else if segments := strings.Split(strings.Trim(route.Path, "/"), "/"); segments[0] != "" {
+			operation.Tags = []string{segments[0]}
+		}
+
+		// Operation IDs must be unique, e.g. showProduct, showProduct2
+		id := utils.Camel(route.HandlerFunc + resource)
+		if id == "" {
+			id = utils.Camel(strings.ToLower(route.Method) + " " + strings.ReplaceAll(route.Path, "/", " "))
+		}
+		operationIDs[id]++
+		if n := operationIDs[id]; n > 1 {
+			id += strconv.Itoa(n)
+		}
+		operation.OperationID = id
+
+		info, ok := handlers[route.HandlerType+"."+route.HandlerFunc]
+		if ok {
+			operation.Summary = firstSentence(info.Doc)
+			for _, name := range info.Query {
+				operation.Parameters = append(operation.Parameters, openAPIParameter{Name: name, In: "query", Schema: &openAPISchema{Type: "string"}})
+			}
+			if info.RequestBody != nil {
+				operation.RequestBody = &openAPIRequestBody{
+					Required: true,
+					Content:  map[string]openAPIMediaType{"application/json": {Schema: info.RequestBody}},
+				}
+			}
+			for _, response := range info.Responses {
+				entry := &openAPIResponse{Description: http.StatusText(response.Status)}
+				if response.Schema != nil {
+					entry.Content = map[string]openAPIMediaType{"application/json": {Schema: response.Schema}}
+				}
+				operation.Responses[strconv.Itoa(response.Status)] = entry
+			}
+		}
+		if len(operation.Responses) == 0 {
+			operation.Responses["default"] = &openAPIResponse{Description: "Response of the handler"}
+		}
+
+		if doc.Paths[openAPIPath] == nil {
+			doc.Paths[openAPIPath] = map[string]*openAPIOperation{}
+		}
+		doc.Paths[openAPIPath][strings.ToLower(route.Method)] = operation
+	}
+
+	// Document the error envelope of the response package with the codes its helpers send
+	if registry.has("response", "ErrorResponse") {
+		registry.ref("response", "ErrorResponse")
+		if code := registry.schemas["response.ErrorResponse"].Properties["code"]; code != nil {
+			code.Enum = errorResponseCodes()
+		}
+	}
+	doc.Components.Schemas = registry.schemas
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// openAPIPathFromGin converts /products/:id to /products/{id} and returns the path parameters
+func openAPIPathFromGin(ginPath string) (string, []openAPIParameter) {
+	var params []openAPIParameter
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if segment == "" || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+		name := segment[1:]
+		schema := &openAPISchema{Type: "string"}
+		if name == "id" || strings.HasSuffix(name, "_id") || strings.HasSuffix(name, "ID") {
+			schema = &openAPISchema{Type: "integer", Format: "int64", Minimum: new(float64)}
+		}
+		params = append(params, openAPIParameter{Name: name, In: "path", Required: true, Schema: schema})
+		segments[i] = "{" + name + "}"
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// findRoutes collects the routes registered on gin engines and groups anywhere in the project
+func findRoutes(root string) ([]routeRegistration, error) {
+	var routes []routeRegistration
+	err := walkGoFiles(root, func(path string) error {
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return nil
+		}
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+				routes = append(routes, routesInFunc(fn)...)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes, nil
+}
+
+// routesInFunc follows router groups and handler variables through a function to find its routes
+func routesInFunc(fn *ast.FuncDecl) []routeRegistration {
+	groups := map[string]string{}   // router or group variable -> path prefix
+	handlers := map[string]string{} // handler variable -> handler type
+	for _, param := range fn.Type.Params.List {
+		if containsString(httpFrameworks["gin"].RouterTypes, exprString(param.Type)) {
+			for _, name := range param.Names {
+				groups[name.Name] = ""
+			}
+		}
+	}
+
+	var routes []routeRegistration
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) != 1 || len(node.Rhs) != 1 {
+				return true
+			}
+			lhs, ok := node.Lhs[0].(*ast.Ident)
+			if !ok {
+				return true
+			}
+			if call, ok := node.Rhs[0].(*ast.CallExpr); ok {
+				switch fun := exprString(call.Fun); {
+				case fun == "gin.Default" || fun == "gin.New":
+					groups[lhs.Name] = ""
+				case strings.HasSuffix(fun, ".Group") && len(call.Args) > 0:
+					parent := strings.TrimSuffix(fun, ".Group")
+					if prefix, ok := groups[parent]; ok {
+						groups[lhs.Name] = joinRoutePath(prefix, stringLiteral(call.Args[0]))
+					}
+				default:
+					if handlerType := handlerTypeOf(call); handlerType != "" {
+						handlers[lhs.Name] = handlerType
+					}
+				}
+			} else if handlerType := handlerTypeOf(node.Rhs[0]); handlerType != "" {
+				handlers[lhs.Name] = handlerType
+			}
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok || !routeMethods[sel.Sel.Name] || len(node.Args) < 2 {
+				return true
+			}
+			receiver, ok := sel.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			prefix, ok := groups[receiver.Name]
+			if !ok {
+				return true
+			}
+
+			route := routeRegistration{Method: sel.Sel.Name, Path: joinRoutePath(prefix, stringLiteral(node.Args[0]))}
+			// The last argument is the handler, the ones before it are middleware
+			if handler, ok := node.Args[len(node.Args)-1].(*ast.SelectorExpr); ok {
+				route.HandlerFunc = handler.Sel.Name
+				if ident, ok := handler.X.(*ast.Ident); ok {
+					route.HandlerType = handlers[ident.Name]
+				} else {
+					route.HandlerType = handlerTypeOf(handler.X)
+				}
+			}
+			routes = append(routes, route)
+		}
+		return true
+	})
+	return routes
+}
+
+// handlerTypeOf resolves expressions such as handlers.NewProductHandler(...) or c.ProductHandler to ProductHandler
+func handlerTypeOf(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		if sel, ok := e.Fun.(*ast.SelectorExpr); ok && strings.HasPrefix(sel.Sel.Name, "New") && strings.HasSuffix(sel.Sel.Name, "Handler") {
+			return strings.TrimPrefix(sel.Sel.Name, "New")
+		}
+	case *ast.SelectorExpr:
+		if strings.HasSuffix(e.Sel.Name, "Handler") {
+			return e.Sel.Name
+		}
+	case *ast.CompositeLit:
+		if sel, ok := e.Type.(*ast.SelectorExpr); ok && strings.HasSuffix(sel.Sel.Name, "Handler") {
+			return sel.Sel.Name
+		}
+	case *ast.UnaryExpr:
+		return handlerTypeOf(e.X)
+	case *ast.ParenExpr:
+		return handlerTypeOf(e.X)
+	}
+	return ""
+}
+
+// joinRoutePath joins a group prefix and a route path the way gin does
+func joinRoutePath(prefix, p string) string {
+	joined := path.Join("/", prefix, p)
+	if strings.HasSuffix(p, "/") && joined != "/" {
+		joined += "/"
+	}
+	return joined
+}
+
+// stringLiteral returns the value of a string literal, or "" for any other expression
+func stringLiteral(expr ast.Expr) string {
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		if value, err := strconv.Unquote(lit.Value); err == nil {
+			return value
+		}
+	}
+	return ""
+}
+
+// analyseHandlers reads the handler methods of the handlers package, keyed by "HandlerType.Method"
+func analyseHandlers(root string, registry *schemaRegistry) (map[string]*handlerInfo, error) {
+	infos := map[string]*handlerInfo{}
+	dir := filepath.Join(root, projectLayout().Handlers)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return infos, nil
+	}
+
+	err := walkGoFiles(dir, func(path string) error {
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil || fn.Recv == nil || len(fn.Recv.List) != 1 {
+				continue
+			}
+			receiver := strings.TrimPrefix(exprString(fn.Recv.List[0].Type), "*")
+			infos[receiver+"."+fn.Name.Name] = analyseHandlerFunc(fn, strings.TrimSuffix(receiver, "Handler"), registry)
+		}
+		return nil
+	})
+	return infos, err
+}
+
+// analyseHandlerFunc finds the request body, query parameters and responses of one handler method
+func analyseHandlerFunc(fn *ast.FuncDecl, resource string, registry *schemaRegistry) *handlerInfo {
+	info := &handlerInfo{}
+	if fn.Doc != nil {
+		info.Doc = fn.Doc.Text()
+	}
+
+	varTypes := map[string]ast.Expr{} // local variable -> declared type, for request bodies
+	varCalls := map[string]string{}   // local variable -> name of the method whose result it holds
+	seen := map[int]bool{}
+	addResponse := func(status int, schema *openAPISchema) {
+		if status == 0 || seen[status] {
+			return
+		}
+		seen[status] = true
+		info.Responses = append(info.Responses, handlerResponse{Status: status, Schema: schema})
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.ValueSpec:
+			for _, name := range node.Names {
+				varTypes[name.Name] = node.Type
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				rhs := node.Rhs[0]
+				if len(node.Rhs) == len(node.Lhs) {
+					rhs = node.Rhs[i]
+				}
+				switch value := rhs.(type) {
+				case *ast.CompositeLit:
+					varTypes[ident.Name] = value.Type
+				case *ast.CallExpr:
+					if sel, ok := value.Fun.(*ast.SelectorExpr); ok {
+						varCalls[ident.Name] = sel.Sel.Name
+					}
+				}
+			}
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			receiver := exprString(sel.X)
+			switch {
+			case receiver == "response":
+				if helper, ok := responseHelpers[sel.Sel.Name]; ok {
+					addResponse(helper.Status, envelopeSchema(registry, helper.Envelope))
+				} else if sel.Sel.Name == "Error" && len(node.Args) > 1 {
+					addResponse(statusCode(node.Args[1]), envelopeSchema(registry, "ErrorResponse"))
+				}
+			case strings.HasPrefix(sel.Sel.Name, "ShouldBind") || strings.HasPrefix(sel.Sel.Name, "Bind"):
+				if sel.Sel.Name == "ShouldBindQuery" || sel.Sel.Name == "BindQuery" || sel.Sel.Name == "ShouldBindUri" || len(node.Args) == 0 {
+					return true
+				}
+				if unary, ok := node.Args[0].(*ast.UnaryExpr); ok {
+					if ident, ok := unary.X.(*ast.Ident); ok && varTypes[ident.Name] != nil {
+						info.RequestBody = registry.typeSchema("dto", varTypes[ident.Name])
+					}
+				}
+			case sel.Sel.Name == "Query" || sel.Sel.Name == "DefaultQuery":
+				if name := stringLiteral(firstArg(node)); name != "" && !containsString(info.Query, name) {
+					info.Query = append(info.Query, name)
+				}
+			case sel.Sel.Name == "JSON" || sel.Sel.Name == "IndentedJSON" || sel.Sel.Name == "AbortWithStatusJSON":
+				if len(node.Args) == 2 {
+					addResponse(statusCode(node.Args[0]), bodySchema(node.Args[1], fn.Name.Name, resource, varCalls, registry))
+				}
+			case sel.Sel.Name == "Status" || sel.Sel.Name == "AbortWithStatus":
+				addResponse(statusCode(firstArg(node)), nil)
+			}
+		}
+		return true
+	})
+
+	sort.Slice(info.Responses, func(i, j int) bool { return info.Responses[i].Status < info.Responses[j].Status })
+	return info
+}
+
+// envelopeSchema references a struct of the response package, falling back to a generic object
+func envelopeSchema(registry *schemaRegistry, name string) *openAPISchema {
+	if name == "" {
+		return nil
+	}
+	if registry.has("response", name) {
+		return registry.ref("response", name)
+	}
+	return &openAPISchema{Type: "object"}
+}
+
+// bodySchema describes a value passed to c.JSON, e.g. gin.H{"data": products} or gin.H{"error": "..."}
+func bodySchema(expr ast.Expr, method, resource string, varCalls map[string]string, registry *schemaRegistry) *openAPISchema {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok || exprString(lit.Type) != "gin.H" {
+		if unary, ok := expr.(*ast.UnaryExpr); ok {
+			expr = unary.X
+		}
+		if lit, ok := expr.(*ast.CompositeLit); ok {
+			return registry.typeSchema("response", lit.Type)
+		}
+		return &openAPISchema{}
+	}
+
+	schema := &openAPISchema{Type: "object", Properties: map[string]*openAPISchema{}}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key := stringLiteral(kv.Key)
+		if key == "" {
+			continue
+		}
+		schema.Required = append(schema.Required, key)
+
+		switch value := kv.Value.(type) {
+		case *ast.BasicLit:
+			if value.Kind == token.STRING {
+				schema.Properties[key] = &openAPISchema{Type: "string"}
+			} else {
+				schema.Properties[key] = &openAPISchema{Type: "number"}
+			}
+		case *ast.CallExpr:
+			// fmt.Sprintf and friends produce messages
+			if strings.HasPrefix(exprString(value.Fun), "fmt.") || strings.HasSuffix(exprString(value.Fun), ".Error") {
+				schema.Properties[key] = &openAPISchema{Type: "string"}
+			} else {
+				schema.Properties[key] = &openAPISchema{}
+			}
+		case *ast.Ident:
+			schema.Properties[key] = resourceValueSchema(value.Name, method, resource, varCalls, registry)
+		default:
+			schema.Properties[key] = &openAPISchema{}
+		}
+	}
+	sort.Strings(schema.Required)
+
+	// gin.H{"error": "..."} bodies share one component
+	if len(schema.Properties) == 1 && schema.Properties["error"] != nil && schema.Properties["error"].Type == "string" {
+		schema.Description = "Error message sent by a handler"
+		registry.schemas["Error"] = schema
+		return &openAPISchema{Ref: "#/components/schemas/Error"}
+	}
+	return schema
+}
+
+// resourceValueSchema guesses whether a handler variable holds one model or a list of them.
+// Variables filled by GetAll/GetPaged/List... or returned from Index are lists.
+func resourceValueSchema(name, method, resource string, varCalls map[string]string, registry *schemaRegistry) *openAPISchema {
+	if resource == "" || !registry.has("models", resource) {
+		return &openAPISchema{}
+	}
+	item := registry.ref("models", resource)
+
+	call := varCalls[name]
+	for _, prefix := range []string{"GetAll", "GetPaged", "List", "FindAll", "Search"} {
+		if strings.HasPrefix(call, prefix) {
+			return &openAPISchema{Type: "array", Items: item}
+		}
+	}
+	if call == "" && method == "Index" {
+		return &openAPISchema{Type: "array", Items: item}
+	}
+	return item
+}
+
+// errorResponseCodes returns the status codes sent by the ErrorResponse helpers, in ascending order
+func errorResponseCodes() []interface{} {
+	var codes []int
+	for _, helper := range responseHelpers {
+		if helper.Envelope == "ErrorResponse" {
+			codes = append(codes, helper.Status)
+		}
+	}
+	sort.Ints(codes)
+
+	enum := make([]interface{}, len(codes))
+	for i, code := range codes {
+		enum[i] = code
+	}
+	return enum
+}
+
+// statusCode resolves http.StatusX constants and integer literals
+func statusCode(expr ast.Expr) int {
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		if exprString(e.X) == "http" {
+			return httpStatusConstants[e.Sel.Name]
+		}
+	case *ast.BasicLit:
+		if e.Kind == token.INT {
+			n, _ := strconv.Atoi(e.Value)
+			return n
+		}
+	}
+	return 0
+}
+
+// firstArg returns the first argument of a call, or nil
+func firstArg(call *ast.CallExpr) ast.Expr {
+	if len(call.Args) == 0 {
+		return nil
+	}
+	return call.Args[0]
+}
@@ -0,0 +1,356 @@
+package commands
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// openAPISchema is the subset of the OpenAPI 3.0 schema object goi generates
+type openAPISchema struct {
+	Ref              string                    `yaml:"$ref,omitempty"`
+	Type             string                    `yaml:"type,omitempty"`
+	Format           string                    `yaml:"format,omitempty"`
+	Description      string                    `yaml:"description,omitempty"`
+	Nullable         bool                      `yaml:"nullable,omitempty"`
+	Enum             []interface{}             `yaml:"enum,omitempty"`
+	Minimum          *float64                  `yaml:"minimum,omitempty"`
+	ExclusiveMinimum bool                      `yaml:"exclusiveMinimum,omitempty"`
+	Maximum          *float64                  `yaml:"maximum,omitempty"`
+	ExclusiveMaximum bool                      `yaml:"exclusiveMaximum,omitempty"`
+	MinLength        *int                      `yaml:"minLength,omitempty"`
+	MaxLength        *int                      `yaml:"maxLength,omitempty"`
+	MinItems         *int                      `yaml:"minItems,omitempty"`
+	MaxItems         *int                      `yaml:"maxItems,omitempty"`
+	Items            *openAPISchema            `yaml:"items,omitempty"`
+	Properties       map[string]*openAPISchema `yaml:"properties,omitempty"`
+	Required         []string                  `yaml:"required,omitempty"`
+}
+
+// schemaPackages are the project packages whose structs can appear in request and response bodies
+var schemaPackages = []string{"dto", "models", "response"}
+
+// schemaRegistry turns the structs of the schema packages into OpenAPI component schemas on demand
+type schemaRegistry struct {
+	structs map[string]*ast.TypeSpec  // "dto.Product" -> declaration
+	docs    map[string]string         // "dto.Product" -> doc comment
+	schemas map[string]*openAPISchema // Component schemas referenced so far
+}
+
+// loadSchemaRegistry parses the struct declarations of the dto, models and response packages
+func loadSchemaRegistry(root string) (*schemaRegistry, error) {
+	registry := &schemaRegistry{
+		structs: map[string]*ast.TypeSpec{},
+		docs:    map[string]string{},
+		schemas: map[string]*openAPISchema{},
+	}
+
+	layout := projectLayout()
+	packageDirs := map[string]string{"dto": layout.DTO, "models": layout.Models, "response": layout.Response}
+	for _, pkg := range schemaPackages {
+		dir := filepath.Join(root, packageDirs[pkg])
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			continue
+		}
+		err := walkGoFiles(dir, func(path string) error {
+			file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					if _, ok := typeSpec.Type.(*ast.StructType); !ok {
+						continue
+					}
+					key := pkg + "." + typeSpec.Name.Name
+					registry.structs[key] = typeSpec
+					if gen.Doc != nil {
+						registry.docs[key] = strings.TrimSpace(gen.Doc.Text())
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// has reports whether the struct pkg.Name is known
+func (r *schemaRegistry) has(pkg, name string) bool {
+	_, ok := r.structs[pkg+"."+name]
+	return ok
+}
+
+// ref returns a reference to the component schema of pkg.Name, building the component the first time
+func (r *schemaRegistry) ref(pkg, name string) *openAPISchema {
+	key := pkg + "." + name
+	typeSpec, ok := r.structs[key]
+	if !ok {
+		return &openAPISchema{Type: "object"}
+	}
+	if _, done := r.schemas[key]; !done {
+		// Register a placeholder first so self-referencing structs terminate
+		schema := &openAPISchema{}
+		r.schemas[key] = schema
+		*schema = *r.structSchema(pkg, typeSpec.Type.(*ast.StructType))
+		schema.Description = firstSentence(r.docs[key])
+	}
+	return &openAPISchema{Ref: "#/components/schemas/" + key}
+}
+
+// structSchema converts a struct to an object schema using its json and binding tags
+func (r *schemaRegistry) structSchema(pkg string, st *ast.StructType) *openAPISchema {
+	schema := &openAPISchema{Type: "object", Properties: map[string]*openAPISchema{}}
+	for _, field := range st.Fields.List {
+		tag := reflect.StructTag("")
+		if field.Tag != nil {
+			if value, err := strconv.Unquote(field.Tag.Value); err == nil {
+				tag = reflect.StructTag(value)
+			}
+		}
+
+		// Embedded structs contribute their fields, like encoding/json does
+		if len(field.Names) == 0 {
+			r.embed(schema, pkg, field.Type)
+			continue
+		}
+
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			jsonName, omitted := jsonFieldName(name.Name, tag.Get("json"))
+			if omitted {
+				continue
+			}
+			property := r.typeSchema(pkg, field.Type)
+			required := applyBindingRules(property, tag.Get("binding"))
+			if field.Doc != nil {
+				property.Description = firstSentence(field.Doc.Text())
+			}
+			schema.Properties[jsonName] = property
+			if required {
+				schema.Required = append(schema.Required, jsonName)
+			}
+		}
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// embed merges the fields of an embedded struct into schema
+func (r *schemaRegistry) embed(schema *openAPISchema, pkg string, expr ast.Expr) {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	switch typeName := exprString(expr); {
+	case typeName == "gorm.Model":
+		// gorm.Model has no json tags, so its fields keep their Go names
+		schema.Properties["ID"] = &openAPISchema{Type: "integer", Format: "int64"}
+		schema.Properties["CreatedAt"] = &openAPISchema{Type: "string", Format: "date-time"}
+		schema.Properties["UpdatedAt"] = &openAPISchema{Type: "string", Format: "date-time"}
+		schema.Properties["DeletedAt"] = &openAPISchema{Type: "string", Format: "date-time", Nullable: true}
+	case !strings.Contains(typeName, "."):
+		if typeSpec, ok := r.structs[pkg+"."+typeName]; ok {
+			embedded := r.structSchema(pkg, typeSpec.Type.(*ast.StructType))
+			for name, property := range embedded.Properties {
+				schema.Properties[name] = property
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+		}
+	}
+}
+
+// typeSchema converts a Go type expression to a schema
+func (r *schemaRegistry) typeSchema(pkg string, expr ast.Expr) *openAPISchema {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if schema := basicTypeSchema(e.Name); schema != nil {
+			return schema
+		}
+		if r.has(pkg, e.Name) {
+			return r.ref(pkg, e.Name)
+		}
+		return &openAPISchema{}
+	case *ast.StarExpr:
+		schema := r.typeSchema(pkg, e.X)
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema
+	case *ast.ArrayType:
+		if ident, ok := e.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return &openAPISchema{Type: "string", Format: "byte"}
+		}
+		return &openAPISchema{Type: "array", Items: r.typeSchema(pkg, e.Elt)}
+	case *ast.MapType:
+		return &openAPISchema{Type: "object"}
+	case *ast.SelectorExpr:
+		switch typeName := exprString(e); typeName {
+		case "time.Time":
+			return &openAPISchema{Type: "string", Format: "date-time"}
+		case "gorm.DeletedAt", "sql.NullTime":
+			return &openAPISchema{Type: "string", Format: "date-time", Nullable: true}
+		case "decimal.Decimal":
+			return &openAPISchema{Type: "number"}
+		case "uuid.UUID":
+			return &openAPISchema{Type: "string", Format: "uuid"}
+		}
+		if x, ok := e.X.(*ast.Ident); ok && r.has(x.Name, e.Sel.Name) {
+			return r.ref(x.Name, e.Sel.Name)
+		}
+		return &openAPISchema{Type: "object"}
+	case *ast.StructType:
+		return r.structSchema(pkg, e)
+	default:
+		// interface{}, any and anything else can hold any JSON value
+		return &openAPISchema{}
+	}
+}
+
+// basicTypeSchema returns the schema of a predeclared Go type, or nil for other identifiers
+func basicTypeSchema(name string) *openAPISchema {
+	switch name {
+	case "string":
+		return &openAPISchema{Type: "string"}
+	case "bool":
+		return &openAPISchema{Type: "boolean"}
+	case "int", "int64", "uint", "uint64":
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case "int8", "int16", "int32", "uint8", "uint16", "uint32", "rune", "byte":
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case "float64":
+		return &openAPISchema{Type: "number", Format: "double"}
+	case "float32":
+		return &openAPISchema{Type: "number", Format: "float"}
+	case "any":
+		return &openAPISchema{}
+	default:
+		return nil
+	}
+}
+
+// jsonFieldName returns the JSON key of a struct field and whether encoding/json skips it
+func jsonFieldName(fieldName, jsonTag string) (string, bool) {
+	name, _, _ := strings.Cut(jsonTag, ",")
+	if name == "-" {
+		return "", true
+	}
+	if name == "" {
+		return fieldName, false
+	}
+	return name, false
+}
+
+// applyBindingRules maps gin validator rules onto schema constraints and reports whether the field is required
+func applyBindingRules(schema *openAPISchema, binding string) bool {
+	required := false
+	for _, rule := range strings.Split(binding, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		number, numErr := strconv.ParseFloat(value, 64)
+		switch key {
+		case "required":
+			required = true
+		case "email":
+			schema.Format = "email"
+		case "url", "uri", "http_url":
+			schema.Format = "uri"
+		case "uuid", "uuid4":
+			schema.Format = "uuid"
+		case "ip", "ipv4":
+			schema.Format = "ipv4"
+		case "ipv6":
+			schema.Format = "ipv6"
+		case "datetime":
+			schema.Format = "date-time"
+		case "oneof":
+			for _, option := range strings.Fields(value) {
+				if schema.Type == "integer" || schema.Type == "number" {
+					if n, err := strconv.ParseFloat(option, 64); err == nil {
+						schema.Enum = append(schema.Enum, n)
+						continue
+					}
+				}
+				schema.Enum = append(schema.Enum, option)
+			}
+		case "min", "gte", "gt":
+			if numErr == nil {
+				setLowerBound(schema, number, key == "gt")
+			}
+		case "max", "lte", "lt":
+			if numErr == nil {
+				setUpperBound(schema, number, key == "lt")
+			}
+		case "len":
+			if numErr == nil {
+				setLowerBound(schema, number, false)
+				setUpperBound(schema, number, false)
+			}
+		}
+	}
+	return required
+}
+
+// setLowerBound applies min/gte/gt, which limit the length of strings and arrays and the value of numbers
+func setLowerBound(schema *openAPISchema, n float64, exclusive bool) {
+	switch schema.Type {
+	case "string":
+		length := int(n)
+		if exclusive {
+			length++
+		}
+		schema.MinLength = &length
+	case "array":
+		length := int(n)
+		if exclusive {
+			length++
+		}
+		schema.MinItems = &length
+	default:
+		schema.Minimum = &n
+		schema.ExclusiveMinimum = exclusive
+	}
+}
+
+// setUpperBound applies max/lte/lt, which limit the length of strings and arrays and the value of numbers
+func setUpperBound(schema *openAPISchema, n float64, exclusive bool) {
+	switch schema.Type {
+	case "string":
+		length := int(n)
+		if exclusive {
+			length--
+		}
+		schema.MaxLength = &length
+	case "array":
+		length := int(n)
+		if exclusive {
+			length--
+		}
+		schema.MaxItems = &length
+	default:
+		schema.Maximum = &n
+		schema.ExclusiveMaximum = exclusive
+	}
+}
+
+// firstSentence returns the first line of a doc comment
+func firstSentence(doc string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(doc), "\n")
+	return strings.TrimSpace(line)
+}
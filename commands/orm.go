@@ -0,0 +1,270 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// makeORM holds the --orm flag of the generators that render models and repositories
+var makeORM string
+
+// defaultORM is used when neither --orm nor goi.yaml selects a backend
+const defaultORM = "gorm"
+
+// ormBackend describes how generated repositories talk to the database
+type ormBackend struct {
+	Name     string // gorm, sqlx, pgx or database/sql
+	DBType   string // Database handle the repositories are built from, e.g. *sqlx.DB
+	DBImport string // Import path of the handle's package
+	Template string // Suffix of the repository template, e.g. "sqlx" for repository_sqlx
+}
+
+// ormBackends lists the supported backends. Every backend implements the same <Name>Repository
+// interface, so handlers and services do not depend on the choice.
+var ormBackends = map[string]ormBackend{
+	"gorm":         {Name: "gorm", DBType: "*gorm.DB", DBImport: "gorm.io/gorm"},
+	"sqlx":         {Name: "sqlx", DBType: "*sqlx.DB", DBImport: "github.com/jmoiron/sqlx", Template: "sqlx"},
+	"pgx":          {Name: "pgx", DBType: "*pgxpool.Pool", DBImport: "github.com/jackc/pgx/v5/pgxpool", Template: "pgx"},
+	"database/sql": {Name: "database/sql", DBType: "*sql.DB", DBImport: "database/sql", Template: "sql"},
+}
+
+// ormAliases are accepted spellings of the backend names
+var ormAliases = map[string]string{
+	"sql":    "database/sql",
+	"stdlib": "database/sql",
+}
+
+// selectedORM returns the backend chosen with --orm, falling back to the orm setting of goi.yaml and then gorm
+func selectedORM() (ormBackend, error) {
+	name := makeORM
+	if name == "" {
+		config, err := loadProjectConfig()
+		if err != nil {
+			return ormBackend{}, err
+		}
+		name = config.ORM
+	}
+	if name == "" {
+		name = defaultORM
+	}
+	if alias, ok := ormAliases[name]; ok {
+		name = alias
+	}
+
+	backend, ok := ormBackends[name]
+	if !ok {
+		return ormBackend{}, fmt.Errorf("unknown ORM %q, expected one of: %s", name, strings.Join(ormNames(), ", "))
+	}
+	return backend, nil
+}
+
+// ormNames returns the supported backend names, sorted
+func ormNames() []string {
+	names := make([]string, 0, len(ormBackends))
+	for name := range ormBackends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// selectedDBType returns the database handle type of the selected backend, used to find where
+// repositories can be constructed. Errors fall back to gorm; they are reported when the files are rendered.
+func selectedDBType() string {
+	backend, err := selectedORM()
+	if err != nil {
+		return ormBackends[defaultORM].DBType
+	}
+	return backend.DBType
+}
+
+// ormTemplateName maps a resource type to the template that renders it for the backend.
+// An empty name means the backend has no such file, e.g. pgx repositories have no SQLite test.
+func ormTemplateName(resourceType string, backend ormBackend) string {
+	if backend.Name == defaultORM {
+		return resourceType
+	}
+	switch resourceType {
+	case "model":
+		return "model_sql"
+	case "repository":
+		return "repository_" + backend.Template
+	case "repository_test":
+		if backend.Name == "pgx" {
+			return ""
+		}
+		return "repository_test_sql"
+	}
+	return resourceType
+}
+
+// sqlTable holds the queries' building blocks for the repositories that write SQL by hand
+type sqlTable struct {
+	Columns        []Field // Every column, primary key first, in scan order
+	Insert         []Field // Columns written by Create
+	Update         []Field // Columns written by the updates
+	Select         string  // Select list; optional columns are coalesced so they scan into plain Go types, or scanned into pointers
+	InsertColumns  string  // e.g. "name, price, created_at, updated_at"
+	InsertValues   string  // Placeholders matching InsertColumns, e.g. "?, ?, ?, ?", "$1, $2, $3, $4" or ":name, ..."
+	UpdateSet      string  // e.g. "name = ?, price = ?, updated_at = ?"
+	UpdateWhere    string  // Condition on the primary key following UpdateSet's placeholders
+	Param          string  // Placeholder of a single-argument query
+	NotDeleted     string  // Condition excluding soft deleted rows, empty when the table has no deleted_at column
+	CreatedAt      string  // Go field holding the creation time, if any
+	CreatedAtValue string  // Value assigned to CreatedAt, "now" or "&now" for a nullable column
+	UpdatedAt      string  // Go field holding the update time, if any
+	UpdatedAtValue string  // Value assigned to UpdatedAt, "now" or "&now" for a nullable column
+	DeletedAt      string  // Column marking soft deleted rows, if any
+	SortColumns    []string
+	SQLiteSchema   string // CREATE TABLE statement used by the repository tests
+}
+
+// defaultSQLBaseFields are the columns every hand-written SQL model has, mirroring gorm.Model.
+// The migrations declare the timestamps NULL, so they are pointers.
+func defaultSQLBaseFields() []Field {
+	return []Field{
+		{FieldName: "ID", ColumnName: "id", FieldType: "uint", JSONName: "id", JsonTag: "id"},
+		{FieldName: "CreatedAt", ColumnName: "created_at", FieldType: "*time.Time", JSONName: "created_at", JsonTag: "created_at", Nullable: true},
+		{FieldName: "UpdatedAt", ColumnName: "updated_at", FieldType: "*time.Time", JSONName: "updated_at", JsonTag: "updated_at", Nullable: true},
+		{FieldName: "DeletedAt", ColumnName: "deleted_at", FieldType: "*time.Time", JSONName: "deleted_at", JsonTag: "deleted_at,omitempty", Nullable: true},
+	}
+}
+
+// sqlScanFields turns optional fields without a COALESCE zero value, such as times, into pointers,
+// so the hand-written repositories can scan a NULL column into them
+func sqlScanFields(fields []Field) []Field {
+	scanned := append([]Field{}, fields...)
+	for i := range scanned {
+		if !scanned[i].Required && sqlZeroValue(scanned[i].FieldType) == "" {
+			scanned[i].nullable()
+		}
+	}
+	return scanned
+}
+
+// newSQLTable builds the query fragments of a table for the backend's placeholder style
+func newSQLTable(backend ormBackend, table string, base, fields []Field) sqlTable {
+	t := sqlTable{Columns: append(append([]Field{}, base...), fields...)}
+
+	// placeholder returns the n-th (1-based) bind parameter for the column
+	placeholder := func(n int, column string) string {
+		switch backend.Name {
+		case "pgx":
+			return "$" + strconv.Itoa(n)
+		case "sqlx":
+			return ":" + column
+		default:
+			return "?"
+		}
+	}
+
+	var selects []string
+	for _, field := range t.Columns {
+		t.SortColumns = append(t.SortColumns, field.ColumnName)
+		if zero := sqlZeroValue(field.FieldType); zero != "" && !field.Required && field.ColumnName != base[0].ColumnName {
+			selects = append(selects, fmt.Sprintf("COALESCE(%s, %s) AS %s", field.ColumnName, zero, field.ColumnName))
+		} else {
+			selects = append(selects, field.ColumnName)
+		}
+	}
+	t.Select = strings.Join(selects, ", ")
+
+	for _, field := range base[1:] {
+		switch field.FieldName {
+		case "CreatedAt":
+			t.CreatedAt, t.CreatedAtValue = field.FieldName, timeValue(field)
+		case "UpdatedAt":
+			t.UpdatedAt, t.UpdatedAtValue = field.FieldName, timeValue(field)
+		case "DeletedAt":
+			t.DeletedAt = field.ColumnName
+			t.NotDeleted = field.ColumnName + " IS NULL"
+		}
+	}
+
+	t.Insert = append([]Field{}, fields...)
+	t.Update = append([]Field{}, fields...)
+	for _, field := range base[1:] {
+		if field.FieldName == "CreatedAt" || field.FieldName == "UpdatedAt" {
+			t.Insert = append(t.Insert, field)
+		}
+		if field.FieldName == "UpdatedAt" {
+			t.Update = append(t.Update, field)
+		}
+	}
+
+	var columns, values, sets []string
+	for i, field := range t.Insert {
+		columns = append(columns, field.ColumnName)
+		values = append(values, placeholder(i+1, field.ColumnName))
+	}
+	for i, field := range t.Update {
+		sets = append(sets, field.ColumnName+" = "+placeholder(i+1, field.ColumnName))
+	}
+	t.InsertColumns = strings.Join(columns, ", ")
+	t.InsertValues = strings.Join(values, ", ")
+	t.UpdateSet = strings.Join(sets, ", ")
+	t.UpdateWhere = base[0].ColumnName + " = " + placeholder(len(t.Update)+1, base[0].ColumnName)
+	t.Param = placeholder(1, base[0].ColumnName)
+	if backend.Name == "sqlx" {
+		// Single-argument queries go through Rebind, which only understands '?'
+		t.Param = "?"
+	}
+
+	t.SQLiteSchema = sqliteSchema(table, t.Columns)
+	return t
+}
+
+// timeValue returns the expression that assigns the current time, now, to a timestamp field
+func timeValue(field Field) string {
+	if strings.HasPrefix(field.FieldType, "*") {
+		return "&now"
+	}
+	return "now"
+}
+
+// sqlZeroValue returns the literal that replaces NULL for the Go type, or "" for types scanned as is
+func sqlZeroValue(goType string) string {
+	switch goType {
+	case "string":
+		return "''"
+	case "bool":
+		return "FALSE"
+	case "int", "int32", "int64", "uint", "uint64", "float32", "float64":
+		return "0"
+	default:
+		return ""
+	}
+}
+
+// sqliteSchema returns the CREATE TABLE statement the repository tests run against SQLite
+func sqliteSchema(table string, columns []Field) string {
+	var defs []string
+	for i, field := range columns {
+		if i == 0 {
+			defs = append(defs, field.ColumnName+" INTEGER PRIMARY KEY AUTOINCREMENT")
+			continue
+		}
+
+		var sqlType string
+		switch strings.TrimPrefix(field.FieldType, "*") {
+		case "string":
+			sqlType = "TEXT"
+		case "bool":
+			sqlType = "BOOLEAN"
+		case "float32", "float64":
+			sqlType = "REAL"
+		case "time.Time":
+			sqlType = "DATETIME"
+		default:
+			sqlType = "INTEGER"
+		}
+		def := field.ColumnName + " " + sqlType
+		if field.Unique {
+			def += " UNIQUE"
+		}
+		defs = append(defs, def)
+	}
+	return fmt.Sprintf("CREATE TABLE %s (%s)", table, strings.Join(defs, ", "))
+}
@@ -0,0 +1,302 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// projectConfigFile holds the project's goi settings, at the project root
+const projectConfigFile = "goi.yaml"
+
+// projectConfig is the content of goi.yaml. Command-line flags take precedence over every setting.
+type projectConfig struct {
+	Main     string         `yaml:"main,omitempty"`     // Main package (directory or main.go) run by serve and compiled by build
+	Build    buildConfig    `yaml:"build,omitempty"`    // Where build writes the binaries
+	Serve    serveConfig    `yaml:"serve,omitempty"`    // How serve runs the project
+	Layout   layoutConfig   `yaml:"layout,omitempty"`   // Directories the generators write into
+	HTTP     string         `yaml:"http,omitempty"`     // HTTP framework used by 'goi make' when --http is not given
+	ORM      string         `yaml:"orm,omitempty"`      // Repository backend used by 'goi make' when --orm is not given
+	Database databaseConfig `yaml:"database,omitempty"` // Connection settings used when neither flags nor the environment set them
+	Deploy   deployConfig   `yaml:"deploy,omitempty"`   // Deploy target and per-target settings
+	Env      []envVar       `yaml:"env,omitempty"`      // Variables of .env, replacing the default schema of 'goi env' when set
+}
+
+// buildConfig configures the binaries written by 'goi build'
+type buildConfig struct {
+	Output         string `yaml:"output,omitempty"`          // Output directory
+	Binary         string `yaml:"binary,omitempty"`          // Binary name, suffixed with the platform
+	VersionPackage string `yaml:"version_package,omitempty"` // Directory of the package stamped with the version, see 'goi make version'
+}
+
+// serveConfig configures 'goi serve'
+type serveConfig struct {
+	Watch     []string        `yaml:"watch,omitempty"`     // Globs watched by --watch besides the Go sources, e.g. templates/**/*.html
+	Restart   string          `yaml:"restart,omitempty"`   // Default --restart policy
+	Processes []processConfig `yaml:"processes,omitempty"` // Processes started by --all, replacing the Procfile
+}
+
+// processConfig is a process started by 'goi serve --all'
+type processConfig struct {
+	Name    string `yaml:"name"`
+	Command string `yaml:"command"`           // Shell command line, e.g. go run ./cmd/worker
+	Restart string `yaml:"restart,omitempty"` // Restart policy of this process, defaulting to serve.restart
+}
+
+// layoutConfig configures the directories of the generated packages and files
+type layoutConfig struct {
+	Handlers   string `yaml:"handlers,omitempty"`
+	DTO        string `yaml:"dto,omitempty"`
+	Models     string `yaml:"models,omitempty"`
+	Services   string `yaml:"services,omitempty"`
+	Repository string `yaml:"repository,omitempty"`
+	Response   string `yaml:"response,omitempty"`
+	Middleware string `yaml:"middleware,omitempty"`
+	Migrations string `yaml:"migrations,omitempty"`
+	Routes     string `yaml:"routes,omitempty"`    // Router setup file created when the project has none
+	Container  string `yaml:"container,omitempty"` // Wiring file updated by 'goi make resource'
+}
+
+// deployConfig configures 'goi deploy'
+type deployConfig struct {
+	Target string       `yaml:"target,omitempty"` // Default --target
+	Docker dockerConfig `yaml:"docker,omitempty"`
+	Heroku herokuConfig `yaml:"heroku,omitempty"`
+}
+
+// dockerConfig configures deployments built with docker
+type dockerConfig struct {
+	Image string `yaml:"image,omitempty"` // Tag of the built image
+}
+
+// herokuConfig configures deployments pushed to Heroku
+type herokuConfig struct {
+	Remote string `yaml:"remote,omitempty"` // Git remote of the Heroku app
+	Branch string `yaml:"branch,omitempty"` // Local branch pushed to the remote
+}
+
+// envVar describes a variable of the project's .env
+type envVar struct {
+	Key         string `yaml:"key"`
+	Default     string `yaml:"default,omitempty"`     // Value written by 'goi env init'
+	Required    bool   `yaml:"required,omitempty"`    // Fails 'goi env check' when missing or empty
+	Description string `yaml:"description,omitempty"` // Comment written above the variable in .env.example
+}
+
+// defaultProjectConfig returns the settings used for everything goi.yaml does not set.
+// The main package, HTTP framework and ORM stay empty because they are detected from the project.
+func defaultProjectConfig() *projectConfig {
+	return &projectConfig{
+		Build: buildConfig{Output: "build", Binary: defaultBinaryName(), VersionPackage: defaultVersionPackage},
+		Layout: layoutConfig{
+			Handlers:   "handlers",
+			DTO:        "dto",
+			Models:     "models",
+			Services:   "services",
+			Repository: "repository",
+			Response:   "response",
+			Middleware: "middleware",
+			Migrations: defaultMigrationsDir,
+			Routes:     defaultRoutesFile,
+			Container:  defaultWireFile,
+		},
+		Database: databaseConfig{Host: "127.0.0.1", Port: "3306"},
+		Deploy: deployConfig{
+			Target: "docker",
+			Docker: dockerConfig{Image: "goi-project"},
+			Heroku: herokuConfig{Remote: "heroku", Branch: "master"},
+		},
+	}
+}
+
+// defaultBinaryName names binaries after the last element of the module path, or the project directory
+func defaultBinaryName() string {
+	if moduleName, err := getModuleNameFromGoMod("."); err == nil && strings.TrimSpace(moduleName) != "" {
+		return path.Base(strings.TrimSpace(moduleName))
+	}
+	if dir, err := os.Getwd(); err == nil {
+		return filepath.Base(dir)
+	}
+	return "app"
+}
+
+// loadProjectConfig reads goi.yaml over the defaults, returning the defaults if it does not exist
+func loadProjectConfig() (*projectConfig, error) {
+	config := defaultProjectConfig()
+	data, err := os.ReadFile(projectConfigFile)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", projectConfigFile, err)
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", projectConfigFile, err)
+	}
+	if err := config.Layout.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", projectConfigFile, err)
+	}
+	return config, nil
+}
+
+// validate checks that every package directory ends in the package name the generated code refers to
+func (l layoutConfig) validate() error {
+	packages := []struct{ key, dir, name string }{
+		{"handlers", l.Handlers, "handlers"},
+		{"dto", l.DTO, "dto"},
+		{"models", l.Models, "models"},
+		{"services", l.Services, "services"},
+		{"repository", l.Repository, "repository"},
+		{"response", l.Response, "response"},
+		{"middleware", l.Middleware, "middleware"},
+	}
+	for _, pkg := range packages {
+		if filepath.Base(filepath.Clean(pkg.dir)) != pkg.name || filepath.IsAbs(pkg.dir) {
+			return fmt.Errorf("layout.%s must be a relative directory named %s, got %q", pkg.key, pkg.name, pkg.dir)
+		}
+	}
+	return nil
+}
+
+// projectLayout returns the generator directories. A goi.yaml that cannot be read falls back to the default
+// layout; the error itself is reported by the ORM and HTTP framework selection that the generators run first.
+func projectLayout() layoutConfig {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return defaultProjectConfig().Layout
+	}
+	return config.Layout
+}
+
+// packageImports are the import paths of the generated packages, available to templates as .Imports
+type packageImports struct {
+	Handlers   string
+	DTO        string
+	Models     string
+	Services   string
+	Repository string
+	Response   string
+}
+
+// newPackageImports returns the import paths of the generated packages for the module and layout
+func newPackageImports(moduleName string, layout layoutConfig) packageImports {
+	importPath := func(dir string) string {
+		return moduleName + "/" + filepath.ToSlash(filepath.Clean(dir))
+	}
+	return packageImports{
+		Handlers:   importPath(layout.Handlers),
+		DTO:        importPath(layout.DTO),
+		Models:     importPath(layout.Models),
+		Services:   importPath(layout.Services),
+		Repository: importPath(layout.Repository),
+		Response:   importPath(layout.Response),
+	}
+}
+
+// configField is one setting of goi.yaml, addressed by its dotted key, e.g. build.binary
+type configField struct {
+	Key   string
+	Value reflect.Value
+}
+
+// configFields lists the single-value settings of the configuration in declaration order.
+// Lists such as env are edited in goi.yaml directly.
+func configFields(config *projectConfig) []configField {
+	var fields []configField
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			key := prefix + strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+			switch v.Field(i).Kind() {
+			case reflect.Struct:
+				walk(v.Field(i), key+".")
+			case reflect.String:
+				fields = append(fields, configField{Key: key, Value: v.Field(i)})
+			}
+		}
+	}
+	walk(reflect.ValueOf(config).Elem(), "")
+	return fields
+}
+
+// lookupConfigField returns the setting with the given dotted key
+func lookupConfigField(config *projectConfig, key string) (configField, error) {
+	var keys []string
+	for _, field := range configFields(config) {
+		if field.Key == key {
+			return field, nil
+		}
+		keys = append(keys, field.Key)
+	}
+	return configField{}, fmt.Errorf("unknown setting %q, expected one of: %s", key, strings.Join(keys, ", "))
+}
+
+// setProjectConfigValue sets a dotted key in goi.yaml, creating the file if needed.
+// The file is edited as a YAML document so comments and the order of the other settings are kept.
+func setProjectConfigValue(key, value string) error {
+	if _, err := lookupConfigField(defaultProjectConfig(), key); err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	data, err := os.ReadFile(projectConfigFile)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", projectConfigFile, err)
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", projectConfigFile, err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	node := doc.Content[0]
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to update %s: the document is not a mapping", projectConfigFile)
+	}
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		child := mappingValue(node, part)
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode}
+			if i == len(parts)-1 {
+				child = &yaml.Node{Kind: yaml.ScalarNode}
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: part}, child)
+		}
+		if i < len(parts)-1 && child.Kind != yaml.MappingNode {
+			return fmt.Errorf("failed to update %s: %s is not a mapping", projectConfigFile, strings.Join(parts[:i+1], "."))
+		}
+		node = child
+	}
+	node.Kind = yaml.ScalarNode
+	node.Tag = "!!str"
+	node.Style = 0
+	node.Value = value
+
+	var buf strings.Builder
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", projectConfigFile, err)
+	}
+
+	// Refuse to write a file that goi could no longer read
+	updated := defaultProjectConfig()
+	if err := yaml.Unmarshal([]byte(buf.String()), updated); err != nil {
+		return fmt.Errorf("failed to update %s: %w", projectConfigFile, err)
+	}
+	if err := updated.Layout.validate(); err != nil {
+		return err
+	}
+	if err := os.WriteFile(projectConfigFile, []byte(buf.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", projectConfigFile, err)
+	}
+	return nil
+}
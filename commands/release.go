@@ -0,0 +1,389 @@
+package commands
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"goi/utils"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Flag variables for 'goi release package'
+var releaseDir string
+var releaseOutput string
+var releaseVersion string
+var releaseFiles []string
+
+// releaseChecksumsFile lists the SHA-256 digest of every archive, in the format of sha256sum
+const releaseChecksumsFile = "SHA256SUMS"
+
+// releaseManifestFile describes the archives of a release for the publishing step
+const releaseManifestFile = "manifest.json"
+
+// releaseDocPatterns are the documents packaged next to the binary when they exist at the project root
+var releaseDocPatterns = []string{"README*", "LICENSE*", "COPYING*"}
+
+// releaseArtifact is a binary of the build directory and the archive it is packaged into
+type releaseArtifact struct {
+	Name    string `json:"name"`   // Archive file name
+	Binary  string `json:"binary"` // Binary name inside the archive
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+	Format  string `json:"format"` // tar.gz or zip
+	Size    int64  `json:"size"`   // Archive size in bytes
+	SHA256  string `json:"sha256"` // Archive digest
+	source  string // Path of the built binary
+	program string // Binary name without the target suffix
+}
+
+// releaseManifest is written to manifest.json
+type releaseManifest struct {
+	Project   string            `json:"project"`
+	Version   string            `json:"version"`
+	Commit    string            `json:"commit"`
+	Dirty     bool              `json:"dirty"`
+	Date      string            `json:"date"`
+	Artifacts []releaseArtifact `json:"artifacts"`
+}
+
+// ReleaseCmd groups the commands that prepare releases
+var ReleaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Prepare release archives from the built binaries",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("subcommand is required. Example: goi release package")
+	},
+}
+
+// ReleasePackageCmd packages the cross-compiled binaries into archives with checksums and a manifest
+var ReleasePackageCmd = &cobra.Command{
+	Use:   "package",
+	Short: "Package the built binaries into archives with SHA256SUMS and a manifest",
+	Long: `The 'package' command packages every <binary>-<os>-<arch> artifact written by
+'goi build' into <binary>-<version>-<os>-<arch>.tar.gz (.zip for Windows). Each
+archive holds the binary, renamed to <binary>, together with the README and
+LICENSE of the project and the files given with --file.
+
+Next to the archives it writes SHA256SUMS, which 'sha256sum -c' can verify, and
+manifest.json listing every archive with its target, size and digest. Nothing
+is uploaded: publishing the release directory is a separate step.
+
+The version is the latest git tag unless --version is given. Archive timestamps
+use SOURCE_DATE_EPOCH when it is set, so the archives can be reproduced.`,
+	Example: `  goi build --target linux/amd64,darwin/arm64,windows/amd64
+  goi release package
+  goi release package --version v1.4.0 --file CHANGELOG.md`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := loadProjectConfig()
+		if err != nil {
+			return err
+		}
+		dir := releaseDir
+		if dir == "" {
+			dir = config.Build.Output
+		}
+		output := releaseOutput
+		if output == "" {
+			output = filepath.Join(dir, "release")
+		}
+
+		info, err := currentBuildInfo(releaseVersion)
+		if err != nil {
+			return err
+		}
+		artifacts, err := findReleaseArtifacts(dir)
+		if err != nil {
+			return err
+		}
+		if len(artifacts) == 0 {
+			return fmt.Errorf("no <binary>-<os>-<arch> artifacts in %s, run 'goi build --target ...' first", dir)
+		}
+		docs, err := releaseDocuments(releaseFiles)
+		if err != nil {
+			return err
+		}
+
+		modTime, err := time.Parse(time.RFC3339, info.BuildDate)
+		if err != nil {
+			return fmt.Errorf("invalid build date %q: %w", info.BuildDate, err)
+		}
+		if err := os.MkdirAll(output, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", output, err)
+		}
+
+		for i := range artifacts {
+			a := &artifacts[i]
+			if err := packageArtifact(a, info.Version, docs, output, modTime); err != nil {
+				return err
+			}
+			utils.PrintSuccess(fmt.Sprintf("Packaged %s into %s (%s)", filepath.ToSlash(a.source), a.Name, formatSize(a.Size)))
+		}
+
+		manifest := releaseManifest{
+			Project:   config.Build.Binary,
+			Version:   info.Version,
+			Commit:    info.Commit,
+			Dirty:     info.Dirty,
+			Date:      info.BuildDate,
+			Artifacts: artifacts,
+		}
+		if err := writeReleaseChecksums(filepath.Join(output, releaseChecksumsFile), artifacts); err != nil {
+			return err
+		}
+		if err := writeReleaseManifest(filepath.Join(output, releaseManifestFile), manifest); err != nil {
+			return err
+		}
+		if info.Dirty {
+			utils.PrintWarning("The working tree has uncommitted changes, the release is marked dirty in the manifest")
+		}
+		utils.PrintSuccess(fmt.Sprintf("Release %s written to %s with %s and %s", info.Version, output, releaseChecksumsFile, releaseManifestFile))
+		return nil
+	},
+}
+
+func init() {
+	ReleaseCmd.AddCommand(ReleasePackageCmd)
+
+	ReleasePackageCmd.Flags().StringVar(&releaseDir, "dir", "", "Directory of the built binaries (defaults to build.output of goi.yaml)")
+	ReleasePackageCmd.Flags().StringVarP(&releaseOutput, "output", "o", "", "Directory the archives are written to (defaults to <dir>/release)")
+	ReleasePackageCmd.Flags().StringVar(&releaseVersion, "version", "", "Version in the archive names (defaults to the latest git tag)")
+	ReleasePackageCmd.Flags().StringSliceVar(&releaseFiles, "file", nil, "Extra file to add to every archive (repeatable)")
+}
+
+// findReleaseArtifacts returns the binaries of dir named <binary>-<os>-<arch>, with .exe for Windows, sorted by name.
+// Targets are recognised from 'go tool dist list', or from the last two parts of the name when it cannot run.
+func findReleaseArtifacts(dir string) ([]releaseArtifact, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	supported, _ := supportedBuildTargets()
+
+	var artifacts []releaseArtifact
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		program, target, ok := parseArtifactName(entry.Name(), supported)
+		if !ok {
+			continue
+		}
+		binary := program
+		if target.OS == "windows" {
+			binary += ".exe"
+		}
+		artifacts = append(artifacts, releaseArtifact{
+			Binary:  binary,
+			OS:      target.OS,
+			Arch:    target.Arch,
+			source:  filepath.Join(dir, entry.Name()),
+			program: program,
+		})
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].source < artifacts[j].source })
+	return artifacts, nil
+}
+
+// parseArtifactName splits <binary>-<os>-<arch>[.exe] into the binary name and the target
+func parseArtifactName(name string, supported map[buildTarget]bool) (string, buildTarget, bool) {
+	base := strings.TrimSuffix(name, ".exe")
+	parts := strings.Split(base, "-")
+	if len(parts) < 3 {
+		return "", buildTarget{}, false
+	}
+	target := buildTarget{OS: parts[len(parts)-2], Arch: parts[len(parts)-1]}
+	if supported != nil && !supported[target] {
+		return "", buildTarget{}, false
+	}
+	// Only Windows binaries carry the .exe suffix
+	if (target.OS == "windows") != (base != name) {
+		return "", buildTarget{}, false
+	}
+	return strings.Join(parts[:len(parts)-2], "-"), target, true
+}
+
+// releaseDocuments returns the README and LICENSE files of the project root followed by the extra files
+func releaseDocuments(extra []string) ([]string, error) {
+	var docs []string
+	for _, pattern := range releaseDocPatterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+		}
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && info.Mode().IsRegular() {
+				docs = append(docs, match)
+			}
+		}
+	}
+	if len(docs) == 0 {
+		utils.PrintWarning("No README or LICENSE found at the project root, the archives only hold the binaries")
+	}
+	for _, file := range extra {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		if !info.Mode().IsRegular() {
+			return nil, fmt.Errorf("%s is not a regular file", file)
+		}
+		if !containsString(docs, file) {
+			docs = append(docs, file)
+		}
+	}
+	return docs, nil
+}
+
+// archiveEntry is a file written into a release archive
+type archiveEntry struct {
+	source string
+	name   string // Path inside the archive
+	mode   os.FileMode
+}
+
+// packageArtifact writes the archive of an artifact into output and records its name, format, size and digest.
+// Files sit in a <binary>-<version>-<os>-<arch> directory so extracting an archive does not scatter them.
+func packageArtifact(a *releaseArtifact, version string, docs []string, output string, modTime time.Time) error {
+	root := fmt.Sprintf("%s-%s-%s-%s", a.program, version, a.OS, a.Arch)
+	entries := []archiveEntry{{source: a.source, name: path.Join(root, a.Binary), mode: 0755}}
+	for _, doc := range docs {
+		entries = append(entries, archiveEntry{source: doc, name: path.Join(root, filepath.Base(doc)), mode: 0644})
+	}
+
+	a.Format = "tar.gz"
+	if a.OS == "windows" {
+		a.Format = "zip"
+	}
+	a.Name = root + "." + a.Format
+	archivePath := filepath.Join(output, a.Name)
+
+	file, err := os.Create(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", archivePath, err)
+	}
+	if a.Format == "zip" {
+		err = writeZipArchive(file, entries, modTime)
+	} else {
+		err = writeTarGzArchive(file, entries, modTime)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(archivePath)
+		return fmt.Errorf("failed to write %s: %w", archivePath, err)
+	}
+
+	a.SHA256, a.Size, err = fileSHA256(archivePath)
+	return err
+}
+
+// writeTarGzArchive writes the entries as a gzip-compressed tarball
+func writeTarGzArchive(w io.Writer, entries []archiveEntry, modTime time.Time) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, entry := range entries {
+		info, err := os.Stat(entry.source)
+		if err != nil {
+			return err
+		}
+		header := &tar.Header{
+			Name:    entry.name,
+			Mode:    int64(entry.mode),
+			Size:    info.Size(),
+			ModTime: modTime,
+			Format:  tar.FormatPAX,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if err := copyFileTo(tw, entry.source); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// writeZipArchive writes the entries as a deflate-compressed zip
+func writeZipArchive(w io.Writer, entries []archiveEntry, modTime time.Time) error {
+	zw := zip.NewWriter(w)
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate, Modified: modTime}
+		header.SetMode(entry.mode)
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if err := copyFileTo(fw, entry.source); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// copyFileTo copies the content of a file into w
+func copyFileTo(w io.Writer, source string) error {
+	file, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// fileSHA256 returns the hex SHA-256 digest and the size of a file
+func fileSHA256(path string) (string, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+// writeReleaseChecksums writes "<digest>  <archive>" lines as sha256sum does
+func writeReleaseChecksums(path string, artifacts []releaseArtifact) error {
+	var b strings.Builder
+	for _, a := range artifacts {
+		fmt.Fprintf(&b, "%s  %s\n", a.SHA256, a.Name)
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeReleaseManifest writes the manifest as indented JSON
+func writeReleaseManifest(path string, manifest releaseManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,411 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"goi/utils"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultRoutesFile is created from the route template when the project has no router setup yet and goi.yaml sets no layout.routes
+const defaultRoutesFile = "routes/routes.go"
+
+// routeSetup describes the function that registers the project's routes
+type routeSetup struct {
+	Path      string // File containing the setup function
+	Fset      *token.FileSet
+	File      *ast.File
+	Func      *ast.FuncDecl
+	Router    string // Identifier of the router (engine, group or mux) inside the function
+	DB        string // Identifier of the database handle parameter, if any
+	Container string // Identifier of the dependency container parameter, if any
+	score     int
+}
+
+// registerResourceRoutes renders the router setup file with the CRUD routes of the resource added.
+// A new setup file takes the container, if any, so the routes use its handler.
+// The returned file is nil when the routes are already registered.
+func registerResourceRoutes(resourceName, moduleName string, container *containerRef) (*generatedFile, error) {
+	framework, err := selectedHTTPFramework()
+	if err != nil {
+		return nil, err
+	}
+	setup, err := findRouteSetup(".", framework)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fall back to a fresh router setup file rendered from the route template
+	if setup == nil {
+		routesFile := projectLayout().Routes
+		if fileExists(routesFile) {
+			return nil, fmt.Errorf("%s exists but declares no function taking a %s to register routes in", routesFile, framework.RouterTypes[0])
+		}
+		content, err := renderRouteTemplate(moduleName, container)
+		if err != nil {
+			return nil, err
+		}
+		setup, err = analyseRouteFile(routesFile, content, framework)
+		if err != nil {
+			return nil, err
+		}
+		if setup == nil {
+			return nil, fmt.Errorf("route template does not declare a function taking a %s", framework.RouterTypes[0])
+		}
+	} else if container != nil && setup.Container == "" && setup.DB != "" {
+		utils.PrintWarning(fmt.Sprintf("%s takes no %s.Container, so the '%s' routes build a second handler from %s; pass the container to %s to use the wired one",
+			setup.Path, container.Package, resourceName, setup.DB, setup.Func.Name.Name))
+	}
+
+	return injectRoutes(setup, resourceName, moduleName, framework)
+}
+
+// injectRoutes adds the CRUD routes of the resource to the setup function, returning nil if they are already there
+func injectRoutes(setup *routeSetup, resourceName, moduleName string, framework httpFramework) (*generatedFile, error) {
+	handlerType := utils.Pascal(resourceName) + "Handler"
+	if routesRegistered(setup.Func, handlerType) {
+		return nil, nil
+	}
+
+	if setup.DB == "" && setup.Container == "" {
+		return nil, fmt.Errorf("cannot register routes in %s: %s has no %s or container parameter to get %s from", setup.Path, setup.Func.Name.Name, selectedDBType(), handlerType)
+	}
+
+	// Add the route statements before the final return, or at the end of the function body
+	body := setup.Func.Body
+	insertStatements(body, routeStatements(astBuilder{pos: statementsPos(body)}, resourceName, setup, framework))
+	if setup.Container == "" {
+		imports := newPackageImports(moduleName, projectLayout())
+		addImports(setup.File, imports.Handlers, imports.Repository, imports.Services)
+	}
+
+	formatted, err := printGoFile(setup.Path, setup.Fset, setup.File)
+	if err != nil {
+		return nil, err
+	}
+
+	return &generatedFile{
+		Path:    setup.Path,
+		Content: formatted,
+		Label:   fmt.Sprintf("Routes for '%s'", resourceName),
+		Modify:  true,
+	}, nil
+}
+
+// renderRouteTemplate renders the router setup file used when the project does not have one yet.
+// The setup function takes the container when one is given, and the database handle otherwise.
+func renderRouteTemplate(moduleName string, container *containerRef) ([]byte, error) {
+	backend, err := selectedORM()
+	if err != nil {
+		return nil, err
+	}
+	framework, err := selectedHTTPFramework()
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := parseTemplateForResource(httpTemplateName("route", framework))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	data := map[string]interface{}{"ModuleName": moduleName, "DBType": backend.DBType, "DBImport": backend.DBImport, "HTTP": framework, "Container": "", "ContainerPackage": ""}
+	if container != nil {
+		data["Container"] = container.Import
+		data["ContainerPackage"] = container.Package
+	}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render route template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// crudRoutes lists the CRUD routes of a resource. Member routes address a single record by its id.
+var crudRoutes = []struct {
+	Method string
+	Action string
+	Member bool
+}{
+	{"GET", "Index", false},
+	{"GET", "Show", true},
+	{"POST", "Create", false},
+	{"PUT", "Update", true},
+	{"DELETE", "Delete", true},
+}
+
+// routeStatements builds the statements that register the CRUD routes of a resource in the framework's style.
+// The handler is taken from the dependency container when the setup function receives one.
+func routeStatements(b astBuilder, resourceName string, setup *routeSetup, framework httpFramework) []ast.Stmt {
+	pascal := utils.Pascal(resourceName)
+	handlerVar := utils.VarName(resourceName) + "Handler"
+	groupVar := utils.VarName(resourceName) + "Routes"
+	path := "/" + utils.Kebab(utils.Plural(resourceName))
+
+	handler := b.expr(setup.Container + "." + pascal + "Handler")
+	if setup.Container == "" {
+		repo := b.call(b.expr("repository.New"+pascal+"Repository"), b.ident(setup.DB))
+		handler = b.call(b.expr("handlers.New"+pascal+"Handler"), b.call(b.expr("services.New"+pascal+"Service"), repo))
+	}
+	stmts := []ast.Stmt{b.assign(b.ident(handlerVar), token.DEFINE, handler)}
+
+	// route registers one action on the router or group, e.g. productRoutes.GET("/:id", productHandler.Show)
+	route := func(router, method, pattern, action string) ast.Stmt {
+		return &ast.ExprStmt{X: b.call(b.expr(router+"."+method), b.str(pattern), b.expr(handlerVar+"."+action))}
+	}
+
+	switch framework.Name {
+	case "chi":
+		var routes []ast.Stmt
+		for _, r := range crudRoutes {
+			pattern := "/"
+			if r.Member {
+				pattern = "/{id}"
+			}
+			routes = append(routes, route("r", r.Method[:1]+strings.ToLower(r.Method[1:]), pattern, r.Action))
+		}
+		mount := b.call(b.expr(setup.Router+".Route"), b.str(path), b.funcLit([]*ast.Field{b.field("r", b.expr("chi.Router"))}, routes))
+		stmts = append(stmts, &ast.ExprStmt{X: mount})
+	case "stdlib":
+		// Method and wildcard patterns need Go 1.22 or later
+		for _, r := range crudRoutes {
+			pattern := r.Method + " " + path
+			if r.Member {
+				pattern += "/{id}"
+			}
+			stmts = append(stmts, route(setup.Router, "HandleFunc", pattern, r.Action))
+		}
+	default:
+		// gin, echo and fiber register routes on a group; fiber names the methods Get, Post, ...
+		stmts = append(stmts, b.assign(b.ident(groupVar), token.DEFINE, b.call(b.expr(setup.Router+".Group"), b.str(path))))
+		for _, r := range crudRoutes {
+			pattern := ""
+			if r.Member {
+				pattern = "/:id"
+			}
+			method := r.Method
+			if framework.Name == "fiber" {
+				method = r.Method[:1] + strings.ToLower(r.Method[1:])
+			}
+			stmts = append(stmts, route(groupVar, method, pattern, r.Action))
+		}
+	}
+	return stmts
+}
+
+// routesRegistered reports whether the function already constructs or references the resource handler
+func routesRegistered(fn *ast.FuncDecl, handlerType string) bool {
+	found := false
+	ast.Inspect(fn, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.SelectorExpr:
+			if node.Sel.Name == "New"+handlerType || node.Sel.Name == handlerType {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// findRouteSetup scans the project for the function that sets up the framework's routes
+func findRouteSetup(root string, framework httpFramework) (*routeSetup, error) {
+	var candidates []*routeSetup
+	err := walkGoFiles(root, func(path string) error {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		setup, err := analyseRouteFile(path, src, framework)
+		if err != nil {
+			// Files that do not parse cannot be edited safely, so they are not candidates
+			return nil
+		}
+		if setup != nil {
+			candidates = append(candidates, setup)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].Path < candidates[j].Path
+	})
+	return candidates[0], nil
+}
+
+// analyseRouteFile looks for a route setup function in a single Go file
+func analyseRouteFile(path string, src []byte, framework httpFramework) (*routeSetup, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var best *routeSetup
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || fn.Recv != nil {
+			continue
+		}
+
+		setup := &routeSetup{Path: path, Fset: fset, File: file, Func: fn}
+		for _, param := range fn.Type.Params.List {
+			typ := exprString(param.Type)
+			for _, name := range param.Names {
+				switch {
+				case containsString(framework.RouterTypes, typ) && setup.Router == "":
+					setup.Router = name.Name
+				case typ == selectedDBType() && setup.DB == "":
+					setup.DB = name.Name
+				case strings.HasSuffix(typ, ".Container") && setup.Container == "":
+					setup.Container = name.Name
+				}
+			}
+		}
+
+		// A router created inside the function, e.g. r := gin.Default()
+		if setup.Router == "" {
+			setup.Router = localRouter(fn, framework)
+		}
+		if setup.Router == "" {
+			continue
+		}
+
+		dir := filepath.Base(filepath.Dir(path))
+		if dir == "routes" || dir == "router" {
+			setup.score += 2
+		}
+		if strings.Contains(strings.ToLower(fn.Name.Name), "route") {
+			setup.score++
+		}
+		if best == nil || setup.score > best.score {
+			best = setup
+		}
+	}
+
+	return best, nil
+}
+
+// localRouter returns the name of a variable assigned from one of the framework's router constructors
+// in the function, e.g. gin.Default() or chi.NewRouter()
+func localRouter(fn *ast.FuncDecl, framework httpFramework) string {
+	name := ""
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			return name == ""
+		}
+		call, ok := assign.Rhs[0].(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if containsString(framework.RouterFuncs, exprString(call.Fun)) {
+			if ident, ok := assign.Lhs[0].(*ast.Ident); ok {
+				name = ident.Name
+			}
+		}
+		return name == ""
+	})
+	return name
+}
+
+// exprString renders simple type expressions such as *gin.Engine or gin.IRouter
+func exprString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.StarExpr:
+		return "*" + exprString(e.X)
+	case *ast.SelectorExpr:
+		return exprString(e.X) + "." + e.Sel.Name
+	default:
+		return ""
+	}
+}
+
+// addImports adds the import paths that the file does not import yet, extending its first import declaration
+func addImports(file *ast.File, paths ...string) {
+	imported := map[string]bool{}
+	for _, spec := range file.Imports {
+		if path, err := strconv.Unquote(spec.Path.Value); err == nil {
+			imported[path] = true
+		}
+	}
+
+	var decl *ast.GenDecl
+	for _, d := range file.Decls {
+		if gen, ok := d.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			decl = gen
+			break
+		}
+	}
+	// New specs sit at the closing parenthesis; a single unparenthesised import gets parentheses
+	b := astBuilder{pos: file.Name.End()}
+	switch {
+	case decl == nil:
+		decl = &ast.GenDecl{TokPos: b.pos, Tok: token.IMPORT}
+		file.Decls = append([]ast.Decl{decl}, file.Decls...)
+	case decl.Rparen.IsValid():
+		b.pos = decl.Rparen
+	default:
+		b.pos = decl.End()
+	}
+	for _, path := range paths {
+		if imported[path] {
+			continue
+		}
+		imported[path] = true
+		spec := &ast.ImportSpec{Path: b.str(path)}
+		decl.Specs = append(decl.Specs, spec)
+		file.Imports = append(file.Imports, spec)
+	}
+	if len(decl.Specs) > 1 && !decl.Lparen.IsValid() {
+		decl.Lparen = decl.Specs[0].Pos()
+	}
+	if decl.Lparen.IsValid() {
+		decl.Rparen = b.pos
+	}
+}
+
+// formatGeneratedSource checks that edited Go source still parses and formats it
+func formatGeneratedSource(path string, src []byte) ([]byte, error) {
+	if _, err := parser.ParseFile(token.NewFileSet(), path, src, parser.ParseComments); err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", path, err)
+	}
+	return formatGoSource(path, src)
+}
+
+// walkGoFiles calls fn for every non-test Go file below root, skipping hidden and vendor directories
+func walkGoFiles(root string, fn func(path string) error) error {
+	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") {
+			return fn(path)
+		}
+		return nil
+	})
+}
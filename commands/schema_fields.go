@@ -0,0 +1,412 @@
+package commands
+
+import (
+	"fmt"
+	"goi/utils"
+	"math"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// makeFrom holds the --from schema reference, e.g. openapi.yaml#/components/schemas/Order
+var makeFrom string
+
+// maxSchemaRefDepth bounds $ref and allOf resolution so cyclic documents cannot loop forever
+const maxSchemaRefDepth = 32
+
+// gormModelColumns are supplied by the gorm.Model embedded in every generated model
+var gormModelColumns = map[string]bool{"id": true, "created_at": true, "updated_at": true, "deleted_at": true}
+
+// loadFields returns the fields of the generated files from --from, --from-table or --fields
+func loadFields() ([]Field, error) {
+	sources := 0
+	for _, source := range []string{makeFields, makeFrom, makeFromTable} {
+		if strings.TrimSpace(source) != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return nil, fmt.Errorf("only one of --fields, --from and --from-table can be given")
+	}
+
+	switch {
+	case makeFrom != "":
+		return fieldsFromSchema(makeFrom)
+	case makeFromTable != "":
+		return fieldsFromTable(makeFromTable, makeDSN)
+	default:
+		return parseFields(makeFields)
+	}
+}
+
+// schemaRefName derives a resource name from a schema reference,
+// e.g. "openapi.yaml#/components/schemas/Order" -> "Order", "order.schema.json" -> "order"
+func schemaRefName(ref string) string {
+	file, pointer, _ := strings.Cut(ref, "#")
+	if pointer = strings.Trim(pointer, "/"); pointer != "" {
+		return unescapePointerToken(path.Base(pointer))
+	}
+	name, _, _ := strings.Cut(path.Base(strings.ReplaceAll(file, "\\", "/")), ".")
+	return name
+}
+
+// fieldsFromSchema reads the object schema at file#pointer from an OpenAPI or JSON Schema document,
+// in YAML or JSON, and converts its properties to fields
+func fieldsFromSchema(ref string) ([]Field, error) {
+	file, pointer, _ := strings.Cut(ref, "#")
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	// YAML is a superset of JSON, so one decoder handles both; nodes keep the property order
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, fmt.Errorf("%s is empty", file)
+	}
+	root := doc.Content[0]
+
+	if pointer == "" && (mappingValue(root, "openapi") != nil || mappingValue(root, "swagger") != nil) {
+		return nil, fmt.Errorf("%s is an OpenAPI document, select a schema with %s#/components/schemas/<Name>", file, file)
+	}
+	schema, err := resolvePointer(root, pointer)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ref, err)
+	}
+
+	reader := &schemaFieldReader{root: root}
+	properties, required, err := reader.properties(schema, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ref, err)
+	}
+	if len(properties) == 0 {
+		return nil, fmt.Errorf("%s: schema has no properties", ref)
+	}
+
+	var fields []Field
+	var skipped []string
+	seen := map[string]bool{}
+	for _, property := range properties {
+		column := utils.Snake(property.Name)
+		if gormModelColumns[column] || seen[column] {
+			continue
+		}
+		typeName, rules, err := reader.fieldType(property.Schema, required[property.Name])
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s (%v)", property.Name, err))
+			continue
+		}
+		field, err := newField(property.Name, typeName, rules)
+		if err != nil {
+			return nil, err
+		}
+		// Keep the JSON keys of the contract, e.g. customerEmail, while the column stays snake_case
+		field.JsonTag = strings.Replace(field.JsonTag, field.JSONName, property.Name, 1)
+		field.JSONName = property.Name
+		seen[column] = true
+		fields = append(fields, field)
+	}
+
+	if len(skipped) > 0 {
+		utils.PrintWarning(fmt.Sprintf("Skipped properties that have no column type, add them by hand: %s", strings.Join(skipped, ", ")))
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%s: no property could be converted to a field", ref)
+	}
+	return fields, nil
+}
+
+// schemaProperty is one entry of a schema's properties, in document order
+type schemaProperty struct {
+	Name   string
+	Schema *yaml.Node
+}
+
+// schemaFieldReader resolves references against the document a schema was read from
+type schemaFieldReader struct {
+	root *yaml.Node
+}
+
+// deref follows local $ref chains to the schema they point to
+func (r *schemaFieldReader) deref(schema *yaml.Node, depth int) (*yaml.Node, error) {
+	for ref := mappingValue(schema, "$ref"); ref != nil; ref = mappingValue(schema, "$ref") {
+		if depth++; depth > maxSchemaRefDepth {
+			return nil, fmt.Errorf("$ref chain is too deep or cyclic")
+		}
+		if !strings.HasPrefix(ref.Value, "#") {
+			return nil, fmt.Errorf("external $ref %q is not supported", ref.Value)
+		}
+		target, err := resolvePointer(r.root, strings.TrimPrefix(ref.Value, "#"))
+		if err != nil {
+			return nil, err
+		}
+		schema = target
+	}
+	return schema, nil
+}
+
+// properties collects the properties and required names of an object schema, merging allOf parts
+func (r *schemaFieldReader) properties(schema *yaml.Node, depth int) ([]schemaProperty, map[string]bool, error) {
+	if depth > maxSchemaRefDepth {
+		return nil, nil, fmt.Errorf("allOf nesting is too deep or cyclic")
+	}
+	schema, err := r.deref(schema, depth)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var properties []schemaProperty
+	required := map[string]bool{}
+	if allOf := mappingValue(schema, "allOf"); allOf != nil && allOf.Kind == yaml.SequenceNode {
+		for _, part := range allOf.Content {
+			partProperties, partRequired, err := r.properties(part, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			properties = append(properties, partProperties...)
+			for name := range partRequired {
+				required[name] = true
+			}
+		}
+	}
+	if props := mappingValue(schema, "properties"); props != nil && props.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(props.Content); i += 2 {
+			properties = append(properties, schemaProperty{Name: props.Content[i].Value, Schema: props.Content[i+1]})
+		}
+	}
+	if names := mappingValue(schema, "required"); names != nil && names.Kind == yaml.SequenceNode {
+		for _, name := range names.Content {
+			required[name.Value] = true
+		}
+	}
+	return properties, required, nil
+}
+
+// fieldType maps a property schema to a short field type name and the binding rules of its constraints
+func (r *schemaFieldReader) fieldType(schema *yaml.Node, required bool) (string, []string, error) {
+	schema, err := r.deref(schema, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	// A single allOf entry is how OpenAPI 3.0 attaches a description or nullable to a $ref
+	if allOf := mappingValue(schema, "allOf"); allOf != nil && len(allOf.Content) == 1 {
+		if schema, err = r.deref(allOf.Content[0], 0); err != nil {
+			return "", nil, err
+		}
+	}
+
+	schemaType := scalarValue(schema, "type")
+	if node := mappingValue(schema, "type"); node != nil && node.Kind == yaml.SequenceNode {
+		// JSON Schema and OpenAPI 3.1 spell nullable types as ["string", "null"]
+		for _, t := range node.Content {
+			if t.Value != "null" {
+				schemaType = t.Value
+			}
+		}
+	}
+	format := scalarValue(schema, "format")
+
+	var typeName string
+	var rules []string
+	switch schemaType {
+	case "string":
+		switch format {
+		case "date-time", "date":
+			typeName = "time"
+		default:
+			typeName = "string"
+			if maxLength, ok := numberValue(schema, "maxLength"); ok && maxLength > 255 {
+				typeName = "text"
+			}
+			if rule, ok := formatRules[format]; ok {
+				rules = append(rules, rule)
+			}
+			if n, ok := numberValue(schema, "minLength"); ok && n > 0 {
+				rules = append(rules, "min="+formatNumber(n))
+			}
+			if n, ok := numberValue(schema, "maxLength"); ok {
+				rules = append(rules, "max="+formatNumber(n))
+			}
+		}
+	case "integer", "number":
+		switch {
+		case schemaType == "number" && format == "float":
+			typeName = "float32"
+		case schemaType == "number":
+			typeName = "float64"
+		case format == "int32":
+			typeName = "int32"
+		case format == "int64":
+			typeName = "int64"
+		default:
+			typeName = "int"
+		}
+		rules = append(rules, boundRules(schema)...)
+	case "boolean":
+		typeName = "bool"
+	case "":
+		return "", nil, fmt.Errorf("no type")
+	default:
+		return "", nil, fmt.Errorf("type %s", schemaType)
+	}
+
+	if rule := enumRule(schema); rule != "" {
+		rules = append(rules, rule)
+	}
+	// gin's required rejects false, so it would make a boolean impossible to unset
+	switch {
+	case required && typeName != "bool" && !isTrue(schema, "readOnly"):
+		rules = append([]string{"required"}, rules...)
+	case len(rules) > 0:
+		// Optional properties are only checked when they are sent
+		rules = append([]string{"omitempty"}, rules...)
+	}
+	return typeName, rules, nil
+}
+
+// formatRules maps string formats to the gin validator rules that check them
+var formatRules = map[string]string{
+	"email": "email",
+	"uri":   "url",
+	"url":   "url",
+	"uuid":  "uuid",
+	"ipv4":  "ipv4",
+	"ipv6":  "ipv6",
+}
+
+// boundRules converts minimum/maximum, including both spellings of exclusive bounds, to gte/gt/lte/lt
+func boundRules(schema *yaml.Node) []string {
+	var rules []string
+	if n, ok := numberValue(schema, "exclusiveMinimum"); ok {
+		rules = append(rules, "gt="+formatNumber(n))
+	} else if n, ok := numberValue(schema, "minimum"); ok {
+		if isTrue(schema, "exclusiveMinimum") {
+			rules = append(rules, "gt="+formatNumber(n))
+		} else {
+			rules = append(rules, "gte="+formatNumber(n))
+		}
+	}
+	if n, ok := numberValue(schema, "exclusiveMaximum"); ok {
+		rules = append(rules, "lt="+formatNumber(n))
+	} else if n, ok := numberValue(schema, "maximum"); ok {
+		if isTrue(schema, "exclusiveMaximum") {
+			rules = append(rules, "lt="+formatNumber(n))
+		} else {
+			rules = append(rules, "lte="+formatNumber(n))
+		}
+	}
+	return rules
+}
+
+// enumRule converts an enum to a oneof rule; values the rule cannot express are left unchecked
+func enumRule(schema *yaml.Node) string {
+	enum := mappingValue(schema, "enum")
+	if enum == nil || enum.Kind != yaml.SequenceNode {
+		return ""
+	}
+	var options []string
+	for _, value := range enum.Content {
+		if value.Tag == "!!null" {
+			continue
+		}
+		if value.Kind != yaml.ScalarNode || value.Value == "" || strings.ContainsAny(value.Value, " ,|'") {
+			return ""
+		}
+		options = append(options, value.Value)
+	}
+	if len(options) == 0 {
+		return ""
+	}
+	return "oneof=" + strings.Join(options, " ")
+}
+
+// resolvePointer resolves a JSON pointer such as /components/schemas/Order against root
+func resolvePointer(root *yaml.Node, pointer string) (*yaml.Node, error) {
+	node := root
+	if pointer == "" || pointer == "/" {
+		return node, nil
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = unescapePointerToken(token)
+		switch node.Kind {
+		case yaml.MappingNode:
+			next := mappingValue(node, token)
+			if next == nil {
+				return nil, fmt.Errorf("%q not found", pointer)
+			}
+			node = next
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				return nil, fmt.Errorf("%q not found", pointer)
+			}
+			node = node.Content[i]
+		default:
+			return nil, fmt.Errorf("%q not found", pointer)
+		}
+	}
+	return node, nil
+}
+
+// unescapePointerToken decodes ~1 and ~0 in a JSON pointer segment
+func unescapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+// mappingValue returns the value of key in a YAML mapping, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalarValue returns the scalar value of key in a YAML mapping, or ""
+func scalarValue(node *yaml.Node, key string) string {
+	if value := mappingValue(node, key); value != nil && value.Kind == yaml.ScalarNode {
+		return value.Value
+	}
+	return ""
+}
+
+// numberValue returns the numeric value of key in a YAML mapping
+func numberValue(node *yaml.Node, key string) (float64, bool) {
+	value := mappingValue(node, key)
+	if value == nil || value.Kind != yaml.ScalarNode || (value.Tag != "!!int" && value.Tag != "!!float") {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(value.Value, 64)
+	return n, err == nil
+}
+
+// isTrue reports whether key is set to true in a YAML mapping
+func isTrue(node *yaml.Node, key string) bool {
+	value := mappingValue(node, key)
+	return value != nil && value.Tag == "!!bool" && value.Value == "true"
+}
+
+// formatNumber writes whole numbers without a decimal point, as validator rules expect
+func formatNumber(n float64) string {
+	if n == math.Trunc(n) {
+		return strconv.FormatInt(int64(n), 10)
+	}
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
@@ -1,11 +1,13 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"goi/utils" // Assuming goi/utils provides PrintSuccess etc.
 	"os"
-	"os/exec"
 	"path/filepath" // For joining paths safely
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -15,37 +17,90 @@ var mainPath string
 
 // ServeProjectCmd is the 'serve' command to start the Go project.
 var ServeProjectCmd = &cobra.Command{
-	Use:   "serve",
+	Use:   "serve [name]",
 	Short: "Start the Go project",
-	Long: `The 'serve' command runs 'go run' to start the Go project.
+	Long: `The 'serve' command builds the Go project into a temporary binary and runs it.
 
-By default, it tries to run 'cmd/api/main.go'. You can specify a different
-path to your main executable file using the --path or -p flag.`,
+By default, it runs the main package set in goi.yaml. Without one, goi looks for
+every 'package main' directory of the module (cmd/api, cmd/worker, ...): when
+there is a single one it is run, when there are several you pick one from a list
+(or, outside a terminal, cmd/api, internal/server or the module root is used).
+Name a main package by its directory name or path to run it directly, or give
+the path to a main file or package directory with the --path or -p flag.
+
+Variables of the project's .env file are passed to the application unless they
+are already set in the environment.
+
+With --watch the project is rebuilt into a temporary binary and restarted whenever
+a Go source, go.mod, go.sum, .env or a file matching --watch-glob (or the serve.watch
+globs of goi.yaml) changes. The old process gets SIGTERM and is killed if it has not
+stopped within --stop-timeout. When a build fails the compiler errors are printed
+and the previous build keeps running.
+
+SIGINT and SIGTERM are forwarded to the project's process group and goi exits
+with the project's exit code. With --restart on-failure a project that exits
+with a non-zero code is restarted after a backoff of 1s, doubling up to 30s.
+
+With --all every process listed in serve.processes of goi.yaml, or in a Procfile
+("name: command" lines), is started side by side with its output prefixed by its
+name. When a process exits the others are stopped, unless its restart policy
+(restart in goi.yaml, or --restart) restarts it:
+
+  serve:
+    processes:
+      - name: api
+        command: go run ./cmd/api
+      - name: worker
+        command: go run ./cmd/worker
+        restart: on-failure`,
+	Example: `  goi serve
+  goi serve worker
+  goi serve --path cmd/api
+  goi serve --watch --watch-glob 'templates/**/*.html'
+  goi serve --restart on-failure
+  goi serve --all`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 && mainPath != "" {
+			return fmt.Errorf("a package name cannot be combined with --path")
+		}
+
 		// Get the current directory where the command is executed
 		projectDir, err := os.Getwd()
 		if err != nil {
 			return fmt.Errorf("failed to get current directory: %w", err)
 		}
 
+		// Run every process of goi.yaml or the Procfile instead of a single main package
+		if serveAllProcesses {
+			if serveWatch || mainPath != "" || len(args) > 0 {
+				return fmt.Errorf("--all cannot be combined with a package name, --watch or --path")
+			}
+			baseEnv := os.Environ()
+			if err := loadEnvFile(filepath.Join(projectDir, defaultEnvFile)); err != nil {
+				return err
+			}
+			return silenceExitCode(cmd, serveAll(projectDir, baseEnv))
+		}
+
 		// Determine the actual path to run
 		targetMainFile := mainPath // Use the value from the flag
 
-		// If the flag was not provided, try to find a default
 		if targetMainFile == "" {
-			// Check for cmd/api/main.go
-			if _, err := os.Stat(filepath.Join(projectDir, "cmd", "api", "main.go")); err == nil {
-				targetMainFile = filepath.Join("cmd", "api", "main.go")
-			} else if _, err := os.Stat(filepath.Join(projectDir, "internal", "server", "main.go")); err == nil {
-				targetMainFile = filepath.Join("internal", "server", "main.go")
-			} else if _, err := os.Stat(filepath.Join(projectDir, "main.go")); err == nil {
-				// Fallback to main.go in the root if cmd/api/main.go not found
-				targetMainFile = "main.go"
-			} else {
-				// If neither common path exists, instruct the user to specify
-				return fmt.Errorf("could not find 'main.go' in default paths (cmd/api/main.go or ./main.go).\n" +
-					"Please specify the path to your main file using 'goi serve --path <your-main-file-path>'")
+			// Otherwise select a main package: the named one, main in goi.yaml or a discovered one
+			config, err := loadProjectConfig()
+			if err != nil {
+				return err
+			}
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
 			}
+			pkg, err := resolveMainPackage(projectDir, name, config)
+			if err != nil {
+				return fmt.Errorf("%w\nName a main package with 'goi serve <name>' or a main file with 'goi serve --path <file>'", err)
+			}
+			targetMainFile = pkg.target()
 		} else {
 			// If a path was provided, make sure it exists
 			fullPath := filepath.Join(projectDir, targetMainFile)
@@ -54,28 +109,49 @@ path to your main executable file using the --path or -p flag.`,
 			}
 		}
 
-		utils.PrintInfo(fmt.Sprintf("Starting Go project from: %s", targetMainFile))
-
-		// Build the 'go run' command
-		runCmd := exec.Command("go", "run", targetMainFile)
-		runCmd.Dir = projectDir // Ensure command runs from the project root
-
-		// Pipe command output to current terminal
-		runCmd.Stdout = os.Stdout
-		runCmd.Stderr = os.Stderr
-		runCmd.Stdin = os.Stdin // Allow input (e.g., if the Go app needs it)
-
-		// Run the command
-		if err := runCmd.Run(); err != nil {
-			return fmt.Errorf("failed to run the project: %w", err)
+		// The project's .env is exported to the application, the environment takes precedence
+		baseEnv := os.Environ()
+		if err := loadEnvFile(filepath.Join(projectDir, defaultEnvFile)); err != nil {
+			return err
 		}
 
-		utils.PrintSuccess("Go project is running successfully!")
-		return nil
+		utils.PrintInfo(fmt.Sprintf("Starting Go project from: %s", targetMainFile))
+		return silenceExitCode(cmd, superviseProject(projectDir, goRunTarget(targetMainFile), baseEnv))
 	},
 }
 
+// silenceExitCode lets the project's exit code become goi's without an error message on top of the project's own output
+func silenceExitCode(cmd *cobra.Command, err error) error {
+	var exitErr *ExitCodeError
+	if errors.As(err, &exitErr) {
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+	}
+	return err
+}
+
+// goRunTarget turns a main file or package directory into an argument for 'go run' and 'go build'
+func goRunTarget(path string) string {
+	if filepath.Clean(path) == "." {
+		return "."
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() && !filepath.IsAbs(path) {
+		return "./" + filepath.ToSlash(filepath.Clean(path))
+	}
+	return path
+}
+
 func init() {
 	// Add the --path flag to the serve command
-	ServeProjectCmd.Flags().StringVarP(&mainPath, "path", "p", "", "Path to the main Go executable file (e.g., cmd/api/main.go or main.go)")
+	ServeProjectCmd.Flags().StringVarP(&mainPath, "path", "p", "", "Path to the main Go executable file or package directory (e.g., cmd/api/main.go or main.go; defaults to main in goi.yaml)")
+
+	// Live reload
+	ServeProjectCmd.Flags().BoolVarP(&serveWatch, "watch", "w", false, "Rebuild and restart the project when its files change")
+	ServeProjectCmd.Flags().StringSliceVar(&serveWatchGlobs, "watch-glob", nil, "Extra files to watch, e.g. 'templates/**/*.html' (repeatable)")
+	ServeProjectCmd.Flags().DurationVar(&serveDebounce, "debounce", 300*time.Millisecond, "Quiet period after a change before rebuilding")
+	ServeProjectCmd.Flags().DurationVar(&serveStopTimeout, "stop-timeout", 5*time.Second, "Time the project has to exit after a signal before it is killed")
+
+	// Supervision
+	ServeProjectCmd.Flags().BoolVar(&serveAllProcesses, "all", false, "Run every process of serve.processes in goi.yaml or the Procfile")
+	ServeProjectCmd.Flags().StringVar(&serveRestart, "restart", "", "Restart policy when the project exits: "+strings.Join(serveRestartPolicies, ", ")+" (defaults to serve.restart of goi.yaml, then no)")
 }
\ No newline at end of file
@@ -0,0 +1,312 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"goi/utils"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// serveAllProcesses starts every process of goi.yaml or the Procfile with 'goi serve --all'
+var serveAllProcesses bool
+
+// procfile lists the processes of 'goi serve --all' when goi.yaml has no serve.processes
+const procfile = "Procfile"
+
+// processNamePattern restricts process names to what a Procfile allows
+var processNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// managedProcess is a process run by 'goi serve --all'
+type managedProcess struct {
+	config    processConfig
+	restart   string
+	cmd       *exec.Cmd
+	stdout    *prefixWriter
+	stderr    *prefixWriter
+	startedAt time.Time
+	restarts  int
+	running   bool
+}
+
+// processExit reports that a managed process exited
+type processExit struct {
+	proc  *managedProcess
+	state *os.ProcessState
+}
+
+// serveAll runs the processes side by side with prefixed output until one of them exits without a restart
+// policy or goi receives SIGINT or SIGTERM, then stops the others. goi exits with the code of the process
+// that ended the run.
+func serveAll(projectDir string, baseEnv []string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+	processes, source, err := loadServeProcesses(config)
+	if err != nil {
+		return err
+	}
+
+	defaultRestart := serveRestart
+	if defaultRestart == "" {
+		defaultRestart = config.Serve.Restart
+	}
+	if defaultRestart == "" {
+		defaultRestart = "no"
+	}
+
+	env, err := envWithFile(baseEnv, filepath.Join(projectDir, defaultEnvFile))
+	if err != nil {
+		return err
+	}
+
+	width := 0
+	for _, p := range processes {
+		width = max(width, len(p.Name))
+	}
+	var outputMu sync.Mutex
+	procs := make([]*managedProcess, len(processes))
+	for i, p := range processes {
+		restart := p.Restart
+		if restart == "" {
+			restart = defaultRestart
+		}
+		if !containsString(serveRestartPolicies, restart) {
+			return fmt.Errorf("unknown restart policy %q for process %s, expected one of: %s", restart, p.Name, strings.Join(serveRestartPolicies, ", "))
+		}
+		prefix := utils.ColorPrefix(p.Name, width, i) + " "
+		procs[i] = &managedProcess{
+			config:  p,
+			restart: restart,
+			stdout:  &prefixWriter{mu: &outputMu, out: os.Stdout, prefix: prefix},
+			stderr:  &prefixWriter{mu: &outputMu, out: os.Stderr, prefix: prefix},
+		}
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	exits := make(chan processExit)
+	// Each process has at most one pending restart, so sends never block once the run is over
+	restarts := make(chan *managedProcess, len(procs))
+
+	start := func(p *managedProcess) error {
+		cmd := shellCommand(p.config.Command)
+		cmd.Dir = projectDir
+		cmd.Env = env
+		cmd.Stdout = p.stdout
+		cmd.Stderr = p.stderr
+		// Do not wait forever for output held open by processes the command left behind
+		cmd.WaitDelay = time.Second
+		setProcessGroup(cmd)
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start process %s: %w", p.config.Name, err)
+		}
+		p.cmd, p.running, p.startedAt = cmd, true, time.Now()
+		go func() {
+			_ = cmd.Wait()
+			p.stdout.Flush()
+			p.stderr.Flush()
+			exits <- processExit{proc: p, state: cmd.ProcessState}
+		}()
+		logServe(utils.PrintSuccess, fmt.Sprintf("Started %s (pid %d): %s", p.config.Name, cmd.Process.Pid, p.config.Command))
+		return nil
+	}
+
+	for _, p := range procs {
+		if err := start(p); err != nil {
+			stopProcesses(procs, exits, syscall.SIGTERM)
+			return err
+		}
+	}
+	logServe(utils.PrintInfo, fmt.Sprintf("Running %d processes from %s", len(procs), source))
+
+	for {
+		select {
+		case sig := <-signals:
+			logServe(utils.PrintInfo, fmt.Sprintf("Received %s, stopping all processes", sig))
+			if code := stopProcesses(procs, exits, sig); code != 0 {
+				return &ExitCodeError{Code: code}
+			}
+			return nil
+
+		case exit := <-exits:
+			p := exit.proc
+			p.running = false
+			code := processExitCode(exit.state)
+			uptime := time.Since(p.startedAt)
+
+			if code != 0 && p.restart == "on-failure" {
+				if uptime >= restartBackoffReset {
+					p.restarts = 0
+				}
+				delay := restartDelay(p.restarts)
+				p.restarts++
+				logServe(utils.PrintWarning, fmt.Sprintf("%s exited with code %d after %s, restarting in %s (restart %d)", p.config.Name, code, uptime.Round(time.Millisecond), delay, p.restarts))
+				time.AfterFunc(delay, func() { restarts <- p })
+				continue
+			}
+
+			logServe(utils.PrintWarning, fmt.Sprintf("%s exited with code %d, stopping the other processes", p.config.Name, code))
+			stopProcesses(procs, exits, syscall.SIGTERM)
+			if code != 0 {
+				return &ExitCodeError{Code: code}
+			}
+			return nil
+
+		case p := <-restarts:
+			if err := start(p); err != nil {
+				stopProcesses(procs, exits, syscall.SIGTERM)
+				return err
+			}
+		}
+	}
+}
+
+// stopProcesses sends sig to every running process group, kills the groups still running after the stop
+// timeout and waits for all of them. It returns the first non-zero exit code.
+func stopProcesses(procs []*managedProcess, exits <-chan processExit, sig os.Signal) int {
+	running := 0
+	for _, p := range procs {
+		if !p.running {
+			continue
+		}
+		if err := signalProcessGroup(p.cmd, sig); err != nil {
+			_ = signalProcessGroup(p.cmd, os.Kill)
+		}
+		running++
+	}
+
+	code := 0
+	timeout := time.After(serveStopTimeout)
+	for running > 0 {
+		select {
+		case exit := <-exits:
+			exit.proc.running = false
+			running--
+			if c := processExitCode(exit.state); code == 0 && c != 0 {
+				code = c
+			}
+		case <-timeout:
+			timeout = nil
+			for _, p := range procs {
+				if p.running {
+					logServe(utils.PrintWarning, fmt.Sprintf("%s did not stop within %s, killing it", p.config.Name, serveStopTimeout))
+					_ = signalProcessGroup(p.cmd, os.Kill)
+				}
+			}
+		}
+	}
+	return code
+}
+
+// loadServeProcesses returns the processes of goi.yaml, or of the Procfile when goi.yaml lists none
+func loadServeProcesses(config *projectConfig) ([]processConfig, string, error) {
+	processes, source := config.Serve.Processes, projectConfigFile
+	if len(processes) == 0 {
+		var err error
+		processes, err = readProcfile(procfile)
+		if os.IsNotExist(err) {
+			return nil, "", fmt.Errorf("no processes to run: add serve.processes to %s or create a %s", projectConfigFile, procfile)
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		source = procfile
+	}
+
+	seen := map[string]bool{}
+	for _, p := range processes {
+		if !processNamePattern.MatchString(p.Name) {
+			return nil, "", fmt.Errorf("invalid process name %q in %s", p.Name, source)
+		}
+		if seen[p.Name] {
+			return nil, "", fmt.Errorf("process %s is declared twice in %s", p.Name, source)
+		}
+		if strings.TrimSpace(p.Command) == "" {
+			return nil, "", fmt.Errorf("process %s in %s has no command", p.Name, source)
+		}
+		seen[p.Name] = true
+	}
+	if len(processes) == 0 {
+		return nil, "", fmt.Errorf("%s declares no processes", source)
+	}
+	return processes, source, nil
+}
+
+// readProcfile parses "name: command" lines, skipping blank lines and # comments
+func readProcfile(path string) ([]processConfig, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var processes []processConfig
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, command, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected name: command", path, lineNumber)
+		}
+		processes = append(processes, processConfig{Name: strings.TrimSpace(name), Command: strings.TrimSpace(command)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return processes, nil
+}
+
+// prefixWriter writes every line of a process's output with the process name in front.
+// Writers of all processes share a mutex so lines are never interleaved.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    []byte // Incomplete last line
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if _, err := fmt.Fprintf(w.out, "%s%s\n", w.prefix, w.buf[:i]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes an output line that did not end with a newline
+func (w *prefixWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) > 0 {
+		fmt.Fprintf(w.out, "%s%s\n", w.prefix, w.buf)
+		w.buf = nil
+	}
+}
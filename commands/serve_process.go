@@ -0,0 +1,275 @@
+package commands
+
+import (
+	"fmt"
+	"goi/utils"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Flag variables for the supervision of 'goi serve'
+var serveRestart string
+var serveStopTimeout time.Duration
+
+// serveRestartPolicies are the accepted --restart values
+var serveRestartPolicies = []string{"no", "on-failure"}
+
+// Restarts after a crash wait restartBackoffMin, doubling up to restartBackoffMax.
+// A process that ran for restartBackoffReset before crashing starts over from the minimum.
+const (
+	restartBackoffMin   = time.Second
+	restartBackoffMax   = 30 * time.Second
+	restartBackoffReset = 10 * time.Second
+)
+
+// ExitCodeError reports that the served project exited with a non-zero code, which goi exits with too
+type ExitCodeError struct {
+	Code int
+}
+
+func (e *ExitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// devServer builds the project into a temporary binary and runs the last build that succeeded
+type devServer struct {
+	projectDir string
+	target     string   // Package or main file passed to go build
+	baseEnv    []string // Environment without .env, so edits to .env apply on the next start
+	envPath    string
+	binDir     string // Temporary directory holding the builds
+	builds     int
+	cmd        *exec.Cmd
+	binary     string
+	startedAt  time.Time
+	exited     chan *os.ProcessState // Receives the state of the process once it exits, nil when nothing runs
+}
+
+// superviseProject builds and runs the project until it exits or goi receives SIGINT or SIGTERM.
+// Signals are forwarded to the project's process group and its exit code is returned as an *ExitCodeError.
+// With --watch the project is rebuilt on changes, with --restart on-failure it is restarted after crashes.
+func superviseProject(projectDir, target string, baseEnv []string) error {
+	config, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+	restart := serveRestart
+	if restart == "" {
+		restart = config.Serve.Restart
+	}
+	if restart == "" {
+		restart = "no"
+	}
+	if !containsString(serveRestartPolicies, restart) {
+		return fmt.Errorf("unknown restart policy %q, expected one of: %s", restart, strings.Join(serveRestartPolicies, ", "))
+	}
+
+	binDir, err := os.MkdirTemp("", "goi-serve-")
+	if err != nil {
+		return fmt.Errorf("failed to create build directory: %w", err)
+	}
+	defer os.RemoveAll(binDir)
+
+	server := &devServer{
+		projectDir: projectDir,
+		target:     target,
+		baseEnv:    baseEnv,
+		envPath:    filepath.Join(projectDir, defaultEnvFile),
+		binDir:     binDir,
+	}
+	defer server.stop(syscall.SIGTERM)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	// Watched files are polled; without --watch the ticker channel stays nil
+	var watcher *projectWatcher
+	var pollTicks <-chan time.Time
+	if serveWatch {
+		watcher, err = newProjectWatcher(projectDir, config)
+		if err != nil {
+			return err
+		}
+		ticker := time.NewTicker(watchPollInterval)
+		defer ticker.Stop()
+		pollTicks = ticker.C
+	}
+
+	if err := server.rebuild(); err != nil {
+		if !serveWatch {
+			return err
+		}
+		logServe(utils.PrintWarning, err.Error())
+	}
+
+	restarts := 0
+	lastCode := 0 // Exit code of the last process, returned when a signal arrives while none runs
+	var restartTimer <-chan time.Time
+	for {
+		select {
+		case sig := <-signals:
+			logServe(utils.PrintInfo, fmt.Sprintf("Received %s, stopping the project", sig))
+			if state := server.stop(sig); state != nil {
+				lastCode = processExitCode(state)
+			}
+			if lastCode != 0 {
+				return &ExitCodeError{Code: lastCode}
+			}
+			return nil
+
+		case state := <-server.exited:
+			code := processExitCode(state)
+			lastCode = code
+			uptime := time.Since(server.startedAt)
+			server.cmd, server.exited = nil, nil
+
+			if code == 0 {
+				logServe(utils.PrintInfo, "Project exited")
+				if !serveWatch {
+					return nil
+				}
+				logServe(utils.PrintInfo, "Waiting for changes")
+				continue
+			}
+
+			logServe(utils.PrintWarning, fmt.Sprintf("Project exited with code %d after %s", code, uptime.Round(time.Millisecond)))
+			if restart == "on-failure" {
+				if uptime >= restartBackoffReset {
+					restarts = 0
+				}
+				delay := restartDelay(restarts)
+				restarts++
+				logServe(utils.PrintInfo, fmt.Sprintf("Restarting in %s (restart %d)", delay, restarts))
+				restartTimer = time.After(delay)
+				continue
+			}
+			if !serveWatch {
+				return &ExitCodeError{Code: code}
+			}
+			logServe(utils.PrintInfo, "Waiting for changes")
+
+		case <-restartTimer:
+			restartTimer = nil
+			if err := server.start(server.binary); err != nil {
+				return err
+			}
+
+		case <-pollTicks:
+			files := watcher.poll(serveDebounce)
+			if len(files) == 0 {
+				continue
+			}
+			logServe(utils.PrintInfo, fmt.Sprintf("%s changed, rebuilding", describeChangedFiles(files)))
+			// A new build replaces a pending restart of the crashed one
+			restartTimer, restarts = nil, 0
+			if err := server.rebuild(); err != nil {
+				logServe(utils.PrintWarning, err.Error())
+			}
+		}
+	}
+}
+
+// rebuild compiles the project and replaces the running process with the new build.
+// When the build fails the compiler errors are returned and the current process keeps running.
+func (s *devServer) rebuild() error {
+	s.builds++
+	binary := filepath.Join(s.binDir, fmt.Sprintf("app-%d", s.builds))
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+
+	build := exec.Command("go", "build", "-o", binary, s.target)
+	build.Dir = s.projectDir
+	output, err := build.CombinedOutput()
+	if err != nil {
+		message := "build failed"
+		if s.cmd != nil {
+			message = "build failed, the previous build keeps running"
+		}
+		return fmt.Errorf("%s:\n%s", message, strings.TrimSpace(string(output)))
+	}
+
+	if s.cmd != nil {
+		s.stop(syscall.SIGTERM)
+	}
+	if s.binary != "" {
+		os.Remove(s.binary)
+	}
+	return s.start(binary)
+}
+
+// start runs a build in its own process group with the current .env applied
+func (s *devServer) start(binary string) error {
+	env, err := envWithFile(s.baseEnv, s.envPath)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(binary)
+	cmd.Dir = s.projectDir
+	cmd.Env = env
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	// The process runs in a background process group, where reading the terminal would stop it with SIGTTIN,
+	// so its stdin is left unattached and reads see end of file
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start the project: %w", err)
+	}
+
+	// Wait errors only report the exit status, which the process state carries
+	exited := make(chan *os.ProcessState, 1)
+	go func() {
+		_ = cmd.Wait()
+		exited <- cmd.ProcessState
+	}()
+	s.cmd, s.binary, s.exited, s.startedAt = cmd, binary, exited, time.Now()
+	logServe(utils.PrintSuccess, fmt.Sprintf("Started build %d (pid %d)", s.builds, cmd.Process.Pid))
+	return nil
+}
+
+// stop sends sig to the process group and kills it if it has not exited within the stop timeout.
+// It returns the state of the exited process, or nil if nothing was running.
+func (s *devServer) stop(sig os.Signal) *os.ProcessState {
+	if s.cmd == nil {
+		return nil
+	}
+	if err := signalProcessGroup(s.cmd, sig); err != nil {
+		_ = signalProcessGroup(s.cmd, os.Kill)
+	}
+
+	var state *os.ProcessState
+	select {
+	case state = <-s.exited:
+	case <-time.After(serveStopTimeout):
+		logServe(utils.PrintWarning, fmt.Sprintf("Process %d did not stop within %s, killing it", s.cmd.Process.Pid, serveStopTimeout))
+		_ = signalProcessGroup(s.cmd, os.Kill)
+		state = <-s.exited
+	}
+	s.cmd, s.exited = nil, nil
+	return state
+}
+
+// restartDelay returns the backoff before the given restart, counting from zero
+func restartDelay(restarts int) time.Duration {
+	delay := restartBackoffMin
+	for i := 0; i < restarts && delay < restartBackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > restartBackoffMax {
+		delay = restartBackoffMax
+	}
+	return delay
+}
+
+// logServe prints a supervision message with a timestamp so restarts can be told apart in container logs
+func logServe(print func(string), message string) {
+	print(time.Now().Format("2006-01-02 15:04:05") + " " + message)
+}
@@ -0,0 +1,27 @@
+//go:build windows
+
+package commands
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on Windows, where processes have no Unix process groups
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// signalProcessGroup signals the process itself. Windows only supports os.Kill, other signals return an error
+// so the caller falls back to killing the process.
+func signalProcessGroup(cmd *exec.Cmd, sig os.Signal) error {
+	return cmd.Process.Signal(sig)
+}
+
+// processExitCode returns the exit code of a process
+func processExitCode(state *os.ProcessState) int {
+	return state.ExitCode()
+}
+
+// shellCommand runs a Procfile command line through cmd.exe
+func shellCommand(command string) *exec.Cmd {
+	return exec.Command("cmd", "/C", command)
+}
@@ -0,0 +1,221 @@
+package commands
+
+import (
+	"fmt"
+	"goi/utils"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Flag variables for 'goi serve --watch'
+var serveWatch bool
+var serveWatchGlobs []string
+var serveDebounce time.Duration
+
+// watchPollInterval is how often the project files are scanned for changes
+const watchPollInterval = 250 * time.Millisecond
+
+// watchIgnoredDirs are never scanned for changes, along with hidden directories and the build output
+var watchIgnoredDirs = map[string]bool{"vendor": true, "node_modules": true, "testdata": true, "tmp": true}
+
+// fileStamp identifies a version of a watched file
+type fileStamp struct {
+	ModTime time.Time
+	Size    int64
+}
+
+// projectWatcher polls the project for changes to the watched files
+type projectWatcher struct {
+	root       string
+	globs      []string
+	skipDirs   map[string]bool
+	snapshot   map[string]fileStamp
+	changed    []string // Changes not reported yet
+	lastChange time.Time
+}
+
+// newProjectWatcher watches the Go sources, go.mod, go.sum, .env and the serve.watch and --watch-glob globs
+func newProjectWatcher(root string, config *projectConfig) (*projectWatcher, error) {
+	globs := append([]string{defaultEnvFile}, config.Serve.Watch...)
+	globs = append(globs, serveWatchGlobs...)
+	w := &projectWatcher{
+		root:     root,
+		globs:    globs,
+		skipDirs: map[string]bool{filepath.ToSlash(filepath.Clean(config.Build.Output)): true},
+	}
+
+	snapshot, err := scanWatchedFiles(root, w.globs, w.skipDirs)
+	if err != nil {
+		return nil, err
+	}
+	w.snapshot = snapshot
+	utils.PrintInfo(fmt.Sprintf("Watching %d files (.go, go.mod, go.sum, %s)", len(snapshot), strings.Join(globs, ", ")))
+	return w, nil
+}
+
+// poll scans the project and returns the changed files once no change has arrived for the debounce period,
+// so a burst of changes (a save-all, a branch switch) triggers a single rebuild
+func (w *projectWatcher) poll(debounce time.Duration) []string {
+	current, err := scanWatchedFiles(w.root, w.globs, w.skipDirs)
+	if err != nil {
+		utils.PrintWarning(err.Error())
+		return nil
+	}
+	if files := changedFiles(w.snapshot, current); len(files) > 0 {
+		w.snapshot = current
+		w.changed = append(w.changed, files...)
+		w.lastChange = time.Now()
+		return nil
+	}
+	if len(w.changed) == 0 || time.Since(w.lastChange) < debounce {
+		return nil
+	}
+	files := w.changed
+	w.changed = nil
+	return files
+}
+
+// scanWatchedFiles stamps the Go sources (tests excluded), go.mod, go.sum and the files matching the globs
+func scanWatchedFiles(root string, globs []string, skipDirs map[string]bool) (map[string]fileStamp, error) {
+	files := map[string]fileStamp{}
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Files removed during the scan are picked up by the next one
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if rel != "." && (strings.HasPrefix(d.Name(), ".") || watchIgnoredDirs[d.Name()] || skipDirs[rel]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isWatchedFile(rel, globs) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		files[rel] = fileStamp{ModTime: info.ModTime(), Size: info.Size()}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s for changes: %w", root, err)
+	}
+	return files, nil
+}
+
+// isWatchedFile reports whether a change to the file (relative, slash-separated) triggers a rebuild
+func isWatchedFile(rel string, globs []string) bool {
+	if strings.HasSuffix(rel, ".go") && !strings.HasSuffix(rel, "_test.go") {
+		return true
+	}
+	if rel == "go.mod" || rel == "go.sum" {
+		return true
+	}
+	for _, glob := range globs {
+		if matchGlob(glob, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches a slash-separated path against a glob where ** spans directories.
+// Globs without a slash match the file name at any depth, e.g. *.html.
+func matchGlob(glob, name string) bool {
+	glob = strings.TrimPrefix(filepath.ToSlash(glob), "./")
+	if !strings.Contains(glob, "/") {
+		ok, _ := path.Match(glob, path.Base(name))
+		return ok
+	}
+	return matchGlobParts(strings.Split(glob, "/"), strings.Split(name, "/"))
+}
+
+// matchGlobParts matches path segments, letting a ** segment consume any number of them
+func matchGlobParts(glob, name []string) bool {
+	for len(glob) > 0 {
+		if glob[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlobParts(glob[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(glob[0], name[0]); !ok {
+			return false
+		}
+		glob, name = glob[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// changedFiles returns the files added, modified or removed between two scans, sorted
+func changedFiles(before, after map[string]fileStamp) []string {
+	var files []string
+	for name, stamp := range after {
+		if previous, ok := before[name]; !ok || previous != stamp {
+			files = append(files, name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			files = append(files, name)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// describeChangedFiles names the first changed files for the rebuild message
+func describeChangedFiles(files []string) string {
+	unique := []string{}
+	for _, file := range files {
+		if !containsString(unique, file) {
+			unique = append(unique, file)
+		}
+	}
+	if len(unique) > 3 {
+		return fmt.Sprintf("%s and %d more", strings.Join(unique[:3], ", "), len(unique)-3)
+	}
+	return strings.Join(unique, ", ")
+}
+
+// envWithFile adds the variables of a .env file that the base environment does not set
+func envWithFile(base []string, envPath string) ([]string, error) {
+	values, err := utils.ReadEnvFile(envPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to load %s: %w", envPath, err)
+	}
+
+	env := append([]string{}, base...)
+	set := map[string]bool{}
+	for _, entry := range base {
+		key, _, _ := strings.Cut(entry, "=")
+		set[key] = true
+	}
+	for key, value := range values {
+		if !set[key] {
+			env = append(env, key+"="+value)
+		}
+	}
+	return env, nil
+}
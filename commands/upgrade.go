@@ -28,7 +28,7 @@ var UpgradeCmd = &cobra.Command{
 
 // upgrade checks for the latest version and updates goi if needed
 func upgrade() error {
-	// Use the CLI_VERSION constant to check the current version
+	// Use CLI_VERSION to check the current version
 	currentVersion := config.CLI_VERSION
 
 	// Fetch the latest release version from GitHub
@@ -0,0 +1,212 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"goi/utils"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// verifySampleFields exercises every field type and rule the generators support
+const verifySampleFields = "name:string:required|min=3,price:float64:gte=0,quantity:int,sku:string:unique,active:bool,released_at:time"
+
+// MakeVerifyCmd renders every template into a temporary module and compiles it
+var MakeVerifyCmd = &cobra.Command{
+	Use:   "verify [name]",
+	Short: "Check that the generator templates compile together",
+	Long: `The 'verify' command renders a sample resource (handler, dto, model, service,
+repository, tests, responses, routes, container and every middleware kind) into a
+temporary module, then runs 'go build', 'go vet' and the generated tests on it.
+Template overrides in .goi/templates are included, so this is the quickest way
+to check a customised template.
+
+Dependencies are resolved with 'go mod tidy', which needs access to the module proxy
+or a warm module cache.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resourceName := "Sample"
+		if len(args) == 1 {
+			resourceName = args[0]
+		}
+
+		spec := makeFields
+		if spec == "" {
+			spec = verifySampleFields
+		}
+		fields, err := parseFields(spec)
+		if err != nil {
+			return err
+		}
+
+		// Use the project's module path when run inside a project so overrides see realistic imports
+		moduleName, err := getModuleNameFromGoMod(".")
+		if err != nil {
+			moduleName = "example.com/goiverify"
+		}
+
+		if err := verifyResource(resourceName, moduleName, fields); err != nil {
+			return err
+		}
+		utils.PrintSuccess("Generated code compiles, passes go vet and its tests pass")
+		return nil
+	},
+}
+
+func init() {
+	MakeVerifyCmd.Flags().StringVar(&makeFields, "fields", "", "Field definitions for the sample resource (defaults to one field of every supported type)")
+}
+
+// verifyResource renders a complete resource into a temporary module and runs go build, go vet and go test on it
+func verifyResource(resourceName, moduleName string, fields []Field) error {
+	files, err := renderVerificationFiles(resourceName, moduleName, fields)
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "goi-verify-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary module: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, file := range files {
+		path := filepath.Join(dir, file.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, file.Content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	// Start from the project's go.mod so dependency versions match, or from a fresh module
+	if data, err := os.ReadFile("go.mod"); err == nil {
+		if err := os.WriteFile(filepath.Join(dir, "go.mod"), data, 0644); err != nil {
+			return fmt.Errorf("failed to write go.mod: %w", err)
+		}
+		if sum, err := os.ReadFile("go.sum"); err == nil {
+			if err := os.WriteFile(filepath.Join(dir, "go.sum"), sum, 0644); err != nil {
+				return fmt.Errorf("failed to write go.sum: %w", err)
+			}
+		}
+	} else if err := runVerifyStep(dir, "go", "mod", "init", moduleName); err != nil {
+		return err
+	}
+
+	utils.PrintInfo(fmt.Sprintf("Verifying generated code for '%s' in %s", resourceName, dir))
+	steps := [][]string{
+		{"go", "mod", "tidy"},
+		{"go", "build", "./..."},
+		{"go", "vet", "./..."},
+		{"go", "test", "./..."},
+	}
+	for _, step := range steps {
+		if err := runVerifyStep(dir, step...); err != nil {
+			if step[1] == "mod" && moduleDownloadFailed(err.Error()) {
+				lines := strings.Split(strings.TrimSpace(err.Error()), "\n")
+				return &verifySkippedError{Reason: strings.TrimSpace(lines[len(lines)-1])}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// verifySkippedError reports that the generated code could not be verified because its dependencies could not be downloaded
+type verifySkippedError struct {
+	Reason string
+}
+
+func (e *verifySkippedError) Error() string {
+	return "Skipped verification of the generated code, its dependencies could not be downloaded (no network access or module proxy?): " + e.Reason
+}
+
+// moduleDownloadFailed reports whether go command output shows that modules could not be fetched
+func moduleDownloadFailed(output string) bool {
+	for _, marker := range []string{"dial tcp", "no such host", "i/o timeout", "connection refused", "network is unreachable", "TLS handshake timeout", "GOPROXY=off", "GOFLAGS=-mod=vendor"} {
+		if strings.Contains(output, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderVerificationFiles renders the resource together with fresh response, route and container files
+func renderVerificationFiles(resourceName, moduleName string, fields []Field) ([]generatedFile, error) {
+	files, err := renderResourceFiles(resourceName, moduleName, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := renderResponseFiles()
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, responses...)
+
+	layout := projectLayout()
+	container, err := renderContainerTemplate(layout.Container)
+	if err != nil {
+		return nil, err
+	}
+	wiring, err := wireSource(layout.Container, container, resourceName, moduleName)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, *wiring)
+
+	ref, err := containerInSource(layout.Container, container, moduleName)
+	if err != nil {
+		return nil, err
+	}
+	routeSrc, err := renderRouteTemplate(moduleName, ref)
+	if err != nil {
+		return nil, err
+	}
+	framework, err := selectedHTTPFramework()
+	if err != nil {
+		return nil, err
+	}
+	setup, err := analyseRouteFile(layout.Routes, routeSrc, framework)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse route template: %w", err)
+	}
+	if setup == nil {
+		return nil, fmt.Errorf("route template does not declare a function taking a %s", framework.RouterTypes[0])
+	}
+	routes, err := injectRoutes(setup, resourceName, moduleName, framework)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, *routes)
+
+	// Every middleware kind, named after itself so they can share the package
+	for _, kind := range middlewareKindNames() {
+		middleware, err := renderMiddlewareFile(kind, kind, moduleName)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, middleware)
+	}
+
+	return files, nil
+}
+
+// runVerifyStep runs a go command in the temporary module and reports its output if it fails
+func runVerifyStep(dir string, args ...string) error {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("generated code failed verification: '%s' failed: %w\n%s", strings.Join(args, " "), err, output.String())
+	}
+	return nil
+}
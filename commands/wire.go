@@ -0,0 +1,275 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"goi/utils"
+	"os"
+	"path/filepath"
+)
+
+// defaultWireFile is the wiring file updated (and created if needed) by 'goi make resource' unless goi.yaml sets layout.container
+const defaultWireFile = "internal/app/container.go"
+
+// wireResource renders the wiring file with the repository, service and handler of the resource injected.
+// Provider sets (wire.NewSet, fx.Provide) get the constructors appended; containers get fields and assignments.
+// The returned file is nil when the resource is already wired.
+func wireResource(resourceName, moduleName, wireFile string) (*generatedFile, error) {
+	src, err := os.ReadFile(wireFile)
+	if os.IsNotExist(err) {
+		src, err = renderContainerTemplate(wireFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wiring file %s: %w", wireFile, err)
+	}
+	return wireSource(wireFile, src, resourceName, moduleName)
+}
+
+// wireSource injects the resource into the given wiring file content, returning nil if it is already wired
+func wireSource(wireFile string, src []byte, resourceName, moduleName string) (*generatedFile, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, wireFile, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse wiring file %s: %w", wireFile, err)
+	}
+
+	pascal := utils.Pascal(resourceName)
+	var changed bool
+	if call := findProviderCall(file); call != nil {
+		changed = wireProviderSet(fset, call, pascal)
+	} else {
+		changed, err = wireContainer(file, pascal)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to wire %s into %s: %w", pascal, wireFile, err)
+	}
+	if !changed {
+		return nil, nil
+	}
+
+	imports := newPackageImports(moduleName, projectLayout())
+	addImports(file, imports.Handlers, imports.Repository, imports.Services)
+	formatted, err := printGoFile(wireFile, fset, file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &generatedFile{
+		Path:    wireFile,
+		Content: formatted,
+		Label:   fmt.Sprintf("Wiring for '%s'", resourceName),
+		Modify:  true,
+	}, nil
+}
+
+// containerRef is the package of a Container struct that route setup functions can take
+type containerRef struct {
+	Import  string // Import path of the package
+	Package string // Package name
+}
+
+// wiredContainer returns the package of the Container declared by the wiring file.
+// It is nil for provider sets (wire.NewSet, fx.Provide) and for containers in package main, which cannot be imported.
+func wiredContainer(wireFile, moduleName string) (*containerRef, error) {
+	src, err := os.ReadFile(wireFile)
+	if os.IsNotExist(err) {
+		src, err = renderContainerTemplate(wireFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wiring file %s: %w", wireFile, err)
+	}
+	return containerInSource(wireFile, src, moduleName)
+}
+
+// containerInSource returns the package of the Container declared by the given wiring file content, if it can be imported
+func containerInSource(wireFile string, src []byte, moduleName string) (*containerRef, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), wireFile, src, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse wiring file %s: %w", wireFile, err)
+	}
+	if container, _ := findContainerStruct(file); container == nil || findProviderCall(file) != nil || file.Name.Name == "main" {
+		return nil, nil
+	}
+	return &containerRef{
+		Import:  moduleName + "/" + filepath.ToSlash(filepath.Clean(filepath.Dir(wireFile))),
+		Package: file.Name.Name,
+	}, nil
+}
+
+// renderContainerTemplate renders a new container file whose package is named after its directory
+func renderContainerTemplate(wireFile string) ([]byte, error) {
+	tmpl, err := parseTemplateForResource("container")
+	if err != nil {
+		return nil, err
+	}
+
+	packageName := filepath.Base(filepath.Dir(wireFile))
+	if packageName == "." || packageName == string(filepath.Separator) {
+		packageName = "main"
+	}
+
+	backend, err := selectedORM()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	data := map[string]interface{}{"PackageName": packageName, "DBType": backend.DBType, "DBImport": backend.DBImport}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render container template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// findProviderCall returns the first wire.NewSet or fx.Provide call in the file
+func findProviderCall(file *ast.File) *ast.CallExpr {
+	var found *ast.CallExpr
+	ast.Inspect(file, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && found == nil {
+			if fun := exprString(call.Fun); fun == "wire.NewSet" || fun == "fx.Provide" {
+				found = call
+			}
+		}
+		return found == nil
+	})
+	return found
+}
+
+// wireProviderSet appends the resource constructors to a provider set call, reporting whether it changed
+func wireProviderSet(fset *token.FileSet, call *ast.CallExpr, pascal string) bool {
+	for _, arg := range call.Args {
+		if exprString(arg) == "handlers.New"+pascal+"Handler" {
+			return false
+		}
+	}
+
+	// A closing parenthesis on its own line stays there, after the new constructors and a trailing comma
+	b := astBuilder{pos: call.Rparen}
+	tokFile := fset.File(call.Rparen)
+	if start := tokFile.LineStart(tokFile.Line(call.Rparen)); len(call.Args) > 0 && start > call.Args[len(call.Args)-1].End() {
+		b.pos = start - 1
+	}
+	call.Args = append(call.Args,
+		b.expr("repository.New"+pascal+"Repository"),
+		b.expr("services.New"+pascal+"Service"),
+		b.expr("handlers.New"+pascal+"Handler"),
+	)
+	return true
+}
+
+// wireContainer adds the resource fields to the Container struct and constructs them in its constructor,
+// reporting whether the file changed
+func wireContainer(file *ast.File, pascal string) (bool, error) {
+	container, dbField := findContainerStruct(file)
+	if container == nil {
+		return false, fmt.Errorf("no Container struct found")
+	}
+	for _, field := range container.Fields.List {
+		for _, name := range field.Names {
+			if name.Name == pascal+"Handler" {
+				return false, nil
+			}
+		}
+	}
+
+	constructor, containerVar, dbParam := findContainerConstructor(file)
+	if constructor == nil {
+		return false, fmt.Errorf("no constructor assigning &Container{...} to a variable found")
+	}
+
+	db := dbParam
+	if dbField != "" {
+		db = containerVar + "." + dbField
+	}
+	if db == "" {
+		return false, fmt.Errorf("neither Container nor its constructor has a %s to build the repository from", selectedDBType())
+	}
+
+	fields := astBuilder{pos: container.Fields.Closing}
+	container.Fields.List = append(container.Fields.List,
+		fields.field(pascal+"Repository", fields.expr("repository."+pascal+"Repository")),
+		fields.field(pascal+"Service", fields.star(fields.expr("services."+pascal+"Service"))),
+		fields.field(pascal+"Handler", fields.star(fields.expr("handlers."+pascal+"Handler"))),
+	)
+
+	// Construct the dependencies just before the constructor's final return
+	body := constructor.Body
+	b := astBuilder{pos: statementsPos(body)}
+	dependency := func(name, constructor, arg string) ast.Stmt {
+		return b.assign(b.expr(containerVar+"."+pascal+name), token.ASSIGN, b.call(b.expr(constructor), b.expr(arg)))
+	}
+	insertStatements(body, []ast.Stmt{
+		dependency("Repository", "repository.New"+pascal+"Repository", db),
+		dependency("Service", "services.New"+pascal+"Service", containerVar+"."+pascal+"Repository"),
+		dependency("Handler", "handlers.New"+pascal+"Handler", containerVar+"."+pascal+"Service"),
+	})
+	return true, nil
+}
+
+// findContainerStruct returns the Container struct type and the name of its database handle field, if any
+func findContainerStruct(file *ast.File) (*ast.StructType, string) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			st, ok := typeSpec.Type.(*ast.StructType)
+			if !ok || typeSpec.Name.Name != "Container" {
+				continue
+			}
+			dbField := ""
+			for _, field := range st.Fields.List {
+				if exprString(field.Type) == selectedDBType() && len(field.Names) > 0 {
+					dbField = field.Names[0].Name
+				}
+			}
+			return st, dbField
+		}
+	}
+	return nil, ""
+}
+
+// findContainerConstructor returns the function that builds the Container, the variable holding it and its database handle parameter
+func findContainerConstructor(file *ast.File) (*ast.FuncDecl, string, string) {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || fn.Recv != nil {
+			continue
+		}
+
+		containerVar := ""
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			assign, ok := n.(*ast.AssignStmt)
+			if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 || containerVar != "" {
+				return containerVar == ""
+			}
+			unary, ok := assign.Rhs[0].(*ast.UnaryExpr)
+			if !ok || unary.Op != token.AND {
+				return true
+			}
+			if lit, ok := unary.X.(*ast.CompositeLit); ok && exprString(lit.Type) == "Container" {
+				if ident, ok := assign.Lhs[0].(*ast.Ident); ok {
+					containerVar = ident.Name
+				}
+			}
+			return true
+		})
+		if containerVar == "" {
+			continue
+		}
+
+		dbParam := ""
+		for _, param := range fn.Type.Params.List {
+			if exprString(param.Type) == selectedDBType() && len(param.Names) > 0 {
+				dbParam = param.Names[0].Name
+			}
+		}
+		return fn, containerVar, dbParam
+	}
+	return nil, "", ""
+}
@@ -1,7 +1,10 @@
 package config
 
+// CLI_VERSION is the version of goi, set at build time with
+// -ldflags "-X goi/config.CLI_VERSION=<version>" (see build.sh)
+var CLI_VERSION = "1.0.2"
+
 // Constants for the project
 const (
-	CLI_VERSION               = "1.0.2"
-	GO_PROJECT_TEMPLATE_URL   = "https://github.com/toewailin/go-project.git"
+	GO_PROJECT_TEMPLATE_URL = "https://github.com/toewailin/go-project.git"
 )
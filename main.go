@@ -1,27 +1,24 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
 	"goi/commands"
+	"goi/config"
 
 	"github.com/spf13/cobra"
 )
 
-const (
-	CLI_VERSION               = "1.0.2"
-	GO_PROJECT_TEMPLATE_URL = "https://github.com/toewailin/go-project.git"
-)
-
 // Main entry point of the application
 func main() {
 	var rootCmd = &cobra.Command{
 		Use:     "goi",
 		Short:   "goi is a CLI tool to manage Go projects",
 		Long:    `goi is a command-line interface tool designed to streamline the creation, management, and deletion of Go projects.`,
-		Version: CLI_VERSION,
+		Version: config.CLI_VERSION,
 	}
 
 	// Add commands to the root command
@@ -31,10 +28,16 @@ func main() {
 	rootCmd.AddCommand(commands.SyncCmd)
 	rootCmd.AddCommand(commands.BuildCmd)
 	rootCmd.AddCommand(commands.DeployCmd)
+	rootCmd.AddCommand(commands.ReleaseCmd)
 	rootCmd.AddCommand(commands.CleanCmd)
 	rootCmd.AddCommand(commands.MakeCmd)
+	rootCmd.AddCommand(commands.DestroyCmd)
 	rootCmd.AddCommand(commands.MySQLBackupCmd)
 	rootCmd.AddCommand(commands.MySQLRestoreCmd)
+	rootCmd.AddCommand(commands.MigrateCmd)
+	rootCmd.AddCommand(commands.ConfigCmd)
+	rootCmd.AddCommand(commands.EnvCmd)
+	rootCmd.AddCommand(commands.OpenAPICmd)
 	rootCmd.AddCommand(commands.InstallCmd)
 	rootCmd.AddCommand(commands.UninstallCmd)
 	rootCmd.AddCommand(commands.UpgradeCmd)
@@ -54,6 +57,11 @@ func main() {
 
 	// Execute the root command
 	if err := rootCmd.Execute(); err != nil {
+		// 'goi serve' exits with the exit code of the project it ran
+		var exitErr *commands.ExitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		fmt.Fprintln(os.Stderr, "ERROR:", fmt.Sprintf("Error executing command: %v", err))
 		os.Exit(1)
 	}
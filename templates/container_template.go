@@ -0,0 +1,22 @@
+package templates
+
+// container_template.go - Template for generating the dependency-injection container that `goi make resource` wires resources into
+const ContainerTemplate = `package {{.PackageName}}
+
+import (
+	"{{.DBImport}}"
+)
+
+// Container holds the application's repositories, services and handlers
+type Container struct {
+	DB {{.DBType}}
+}
+
+// NewContainer constructs every dependency of the application
+func NewContainer(db {{.DBType}}) *Container {
+	c := &Container{DB: db}
+
+	// Resources are wired below by 'goi make resource'
+	return c
+}
+`
@@ -0,0 +1,619 @@
+package templates
+
+// echo_template.go - Templates for projects whose router is echo (goi make --http echo)
+
+// HandlerEchoTemplate - Template for generating echo handlers
+const HandlerEchoTemplate = `package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"{{.Imports.DTO}}"        // Import the request DTOs
+	"{{.Imports.Services}}"   // Import the service layer
+	"github.com/go-playground/validator/v10" // Checks the DTO binding rules, as gin does
+	"github.com/labstack/echo/v4" // Import Echo framework
+)
+
+// {{.HandlerName}}Handler handles requests for {{.HandlerName}} resources
+type {{.HandlerName}}Handler struct {
+	service  *services.{{.HandlerName}}Service
+	validate *validator.Validate
+}
+
+// New{{.HandlerName}}Handler creates a new instance of {{.HandlerName}}Handler
+func New{{.HandlerName}}Handler(service *services.{{.HandlerName}}Service) *{{.HandlerName}}Handler {
+	validate := validator.New()
+	validate.SetTagName("binding")
+	return &{{.HandlerName}}Handler{service: service, validate: validate}
+}
+
+// Index handles GET requests for {{.HandlerName}} resources
+func (h *{{.HandlerName}}Handler) Index(c echo.Context) error {
+	// Get all {{.HandlerName}} resources from the service
+	{{.VarPlural}}, err := h.service.GetAll()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": fmt.Sprintf("Failed to retrieve %s resources: %v", "{{.HandlerName}}", err)})
+	}
+	return c.JSON(http.StatusOK, echo.Map{"data": {{.VarPlural}}})
+}
+
+// Show handles GET requests for a single {{.HandlerName}} resource by ID
+func (h *{{.HandlerName}}Handler) Show(c echo.Context) error {
+	// Get the ID from the URL parameters
+	id, err := h.parseID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Invalid ID: %v", err)})
+	}
+
+	// Fetch the resource by ID through the service
+	{{.Var}}, err := h.service.GetByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": fmt.Sprintf("%s resource not found: %v", "{{.HandlerName}}", err)})
+	}
+	return c.JSON(http.StatusOK, echo.Map{"data": {{.Var}}})
+}
+
+// Create handles POST requests to create a new {{.HandlerName}} resource
+func (h *{{.HandlerName}}Handler) Create(c echo.Context) error {
+	var req dto.{{.HandlerName}}
+
+	// Bind and validate the request body against the {{.HandlerName}} DTO
+	if err := h.bind(c, &req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Invalid input: %v", err)})
+	}
+
+	// Call the service to create the resource
+	created{{.HandlerName}}, err := h.service.Create(&req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": fmt.Sprintf("Failed to create %s resource: %v", "{{.HandlerName}}", err)})
+	}
+	return c.JSON(http.StatusCreated, echo.Map{"data": created{{.HandlerName}}})
+}
+
+// Update handles PUT requests to update an existing {{.HandlerName}} resource
+func (h *{{.HandlerName}}Handler) Update(c echo.Context) error {
+	id, err := h.parseID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Invalid ID: %v", err)})
+	}
+	var req dto.{{.HandlerName}}
+
+	// Bind and validate the request body against the {{.HandlerName}} DTO
+	if err := h.bind(c, &req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Invalid input: %v", err)})
+	}
+
+	// Update the resource through the service
+	updated{{.HandlerName}}, err := h.service.Update(id, &req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": fmt.Sprintf("Failed to update %s resource: %v", "{{.HandlerName}}", err)})
+	}
+	return c.JSON(http.StatusOK, echo.Map{"data": updated{{.HandlerName}}})
+}
+
+// Delete handles DELETE requests to remove a {{.HandlerName}} resource by ID
+func (h *{{.HandlerName}}Handler) Delete(c echo.Context) error {
+	id, err := h.parseID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Invalid ID: %v", err)})
+	}
+
+	// Delete the resource through the service
+	if err := h.service.Delete(id); err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": fmt.Sprintf("Failed to delete %s resource: %v", "{{.HandlerName}}", err)})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// bind decodes the JSON request body into req and checks its binding rules
+func (h *{{.HandlerName}}Handler) bind(c echo.Context, req *dto.{{.HandlerName}}) error {
+	if err := (&echo.DefaultBinder{}).BindBody(c, req); err != nil {
+		return err
+	}
+	return h.validate.Struct(req)
+}
+
+// parseID reads the numeric "id" URL parameter
+func (h *{{.HandlerName}}Handler) parseID(c echo.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+`
+
+// SuccessResponseEchoTemplate - Template for generating SuccessResponse for echo
+const SuccessResponseEchoTemplate = `package response
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SuccessResponse struct represents the structure of a success response
+type SuccessResponse struct {
+	Code    int         ` + "`" + `json:"code"` + "`" + `
+	Message string      ` + "`" + `json:"message"` + "`" + `
+	Data    interface{} ` + "`" + `json:"data,omitempty"` + "`" + `
+}
+
+// Success sends a standardized success response
+func Success(c echo.Context, data interface{}, message string) error {
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Code:    http.StatusOK,
+		Message: message,
+		Data:    data,
+	})
+}
+
+// NoContentResponse struct represents the structure of a no content response (204)
+type NoContentResponse struct {
+	Code    int    ` + "`" + `json:"code"` + "`" + `
+	Message string ` + "`" + `json:"message"` + "`" + `
+}
+
+// SendNoContent sends a standardized no content response
+func SendNoContent(c echo.Context, message string) error {
+	return c.JSON(http.StatusNoContent, NoContentResponse{
+		Code:    http.StatusNoContent,
+		Message: message,
+	})
+}
+`
+
+// ErrorResponseEchoTemplate - Template for generating ErrorResponse for echo
+const ErrorResponseEchoTemplate = `package response
+
+import (
+	"fmt" // Added for the InternalError function
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ErrorResponse struct {
+	Code    int         ` + "`" + `json:"code"` + "`" + `
+	Message string      ` + "`" + `json:"message"` + "`" + `
+	Errors  interface{} ` + "`" + `json:"errors,omitempty"` + "`" + `
+}
+
+func Error(c echo.Context, code int, message string, errors interface{}) error {
+	return c.JSON(code, ErrorResponse{
+		Code:    code,
+		Message: message,
+		Errors:  errors,
+	})
+}
+
+func BadRequest(c echo.Context, message string, errors interface{}) error {
+	return Error(c, http.StatusBadRequest, message, errors)
+}
+
+func Unauthorized(c echo.Context, message string) error {
+	return Error(c, http.StatusUnauthorized, message, nil)
+}
+
+func InternalError(c echo.Context, err error) error {
+	return Error(c, http.StatusInternalServerError, "Internal Server Error", fmt.Sprintf("error: %v", err))
+}
+
+func ValidationError(c echo.Context, errs map[string]string) error {
+	return Error(c, http.StatusUnprocessableEntity, "Validation failed", errs)
+}
+
+func NotFoundResponse(c echo.Context, message string) error {
+	return Error(c, http.StatusNotFound, message, nil)
+}
+`
+
+// PaginationResponseEchoTemplate - Template for generating pagination response for echo
+const PaginationResponseEchoTemplate = `package response
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Pagination struct {
+	Page       int         ` + "`" + `json:"page"` + "`" + `
+	Limit      int         ` + "`" + `json:"limit"` + "`" + `
+	TotalRows  int64       ` + "`" + `json:"total_rows"` + "`" + `
+	TotalPages int         ` + "`" + `json:"total_pages"` + "`" + `
+	Data       interface{} ` + "`" + `json:"data"` + "`" + `
+}
+
+func Paginated(c echo.Context, data interface{}, page, limit int, totalRows int64) error {
+	totalPages := int((totalRows + int64(limit) - 1) / int64(limit)) // ceil
+
+	return c.JSON(http.StatusOK, Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalRows:  totalRows,
+		TotalPages: totalPages,
+		Data:       data,
+	})
+}
+`
+
+// RouteEchoTemplate - Template for generating the echo router setup file that ` + "`goi make resource`" + ` registers routes in
+const RouteEchoTemplate = `package routes
+
+import (
+	{{if .Container}}"{{.Container}}"{{else}}"{{.DBImport}}"{{end}}
+	"github.com/labstack/echo/v4"
+)
+
+// SetupRoutes sets up the routes for the application
+func SetupRoutes(e *echo.Echo, {{if .Container}}c *{{.ContainerPackage}}.Container{{else}}db {{.DBType}}{{end}}) {
+	// Resource routes are registered below by 'goi make resource'
+}
+`
+
+// HandlerTestEchoTemplate - Template for the CRUD handler tests of echo handlers, backed by a fake repository
+const HandlerTestEchoTemplate = `package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+{{- if .NeedsTime}}
+	"time"
+{{- end}}
+
+	"{{.Imports.Models}}"
+	"{{.Imports.Services}}"
+	"github.com/labstack/echo/v4"
+)
+
+` + handlerTestFakes + `// new{{.Name}}TestRouter routes the CRUD endpoints to a handler backed by repo
+func new{{.Name}}TestRouter(repo *fake{{.Name}}Repository) *echo.Echo {
+	e := echo.New()
+	h := New{{.Name}}Handler(services.New{{.Name}}Service(repo))
+	e.GET("/{{.KebabPlural}}", h.Index)
+	e.GET("/{{.KebabPlural}}/:id", h.Show)
+	e.POST("/{{.KebabPlural}}", h.Create)
+	e.PUT("/{{.KebabPlural}}/:id", h.Update)
+	e.DELETE("/{{.KebabPlural}}/:id", h.Delete)
+	return e
+}
+
+` + handlerTestCases
+
+// BlankMiddlewareEchoTemplate - Template for an empty echo middleware skeleton
+const BlankMiddlewareEchoTemplate = `package middleware
+
+import "github.com/labstack/echo/v4"
+
+// {{.Name}}Middleware runs before the handlers of the routes it is attached to
+func {{.Name}}Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// Add your logic before the handler here
+
+			err := next(c)
+
+			// Add your logic after the handler here
+			return err
+		}
+	}
+}
+`
+
+// JWTMiddlewareEchoTemplate - Template for an echo middleware that verifies RS256 JWTs with the key generated by 'goi keys'
+const JWTMiddlewareEchoTemplate = `package middleware
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// {{.Name}}PublicKeyPath is the RSA public key generated by 'goi keys'
+const {{.Name}}PublicKeyPath = "config/rsa_public.pem"
+
+// {{.Name}}ClaimsKey is the echo context key holding the verified token claims
+const {{.Name}}ClaimsKey = "claims"
+
+// {{.Name}}Middleware rejects requests without a valid "Authorization: Bearer <token>" header.
+// Tokens must be signed with RS256 by the private key matching {{.Name}}PublicKeyPath.
+func {{.Name}}Middleware() echo.MiddlewareFunc {
+	var (
+		mu        sync.Mutex
+		publicKey *rsa.PublicKey
+	)
+	// loadKey keeps the key once it has loaded, and retries a failed load on the next request
+	loadKey := func() (*rsa.PublicKey, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if publicKey == nil {
+			key, err := load{{.Name}}PublicKey({{.Name}}PublicKeyPath)
+			if err != nil {
+				return nil, err
+			}
+			publicKey = key
+		}
+		return publicKey, nil
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key, err := loadKey()
+			if err != nil {
+				return c.JSON(http.StatusInternalServerError, echo.Map{"error": "authentication is not configured"})
+			}
+
+			header := c.Request().Header.Get("Authorization")
+			tokenString, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || tokenString == "" {
+				return c.JSON(http.StatusUnauthorized, echo.Map{"error": "missing bearer token"})
+			}
+
+			claims := jwt.MapClaims{}
+			token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+				return key, nil
+			}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+			if err != nil || !token.Valid {
+				return c.JSON(http.StatusUnauthorized, echo.Map{"error": "invalid or expired token"})
+			}
+
+			c.Set({{.Name}}ClaimsKey, claims)
+			return next(c)
+		}
+	}
+}
+
+// load{{.Name}}PublicKey reads a PEM encoded RSA public key (PKIX or PKCS#1)
+func load{{.Name}}PublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key %s: %w", path, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("public key in %s is not an RSA key", path)
+		}
+		return rsaKey, nil
+	}
+	return x509.ParsePKCS1PublicKey(block.Bytes)
+}
+`
+
+// CORSMiddlewareEchoTemplate - Template for an echo CORS middleware
+const CORSMiddlewareEchoTemplate = `package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// {{.Name}}AllowedOrigins lists the origins allowed to call the API; "*" allows any origin
+var {{.Name}}AllowedOrigins = []string{"*"}
+
+// {{.Name}}Middleware adds the CORS headers and answers preflight requests
+func {{.Name}}Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			origin := c.Request().Header.Get("Origin")
+			if origin != "" && {{.Var}}OriginAllowed(origin) {
+				header := c.Response().Header()
+				header.Set("Access-Control-Allow-Origin", origin)
+				header.Set("Vary", "Origin")
+				header.Set("Access-Control-Allow-Credentials", "true")
+				header.Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+				header.Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-Request-ID")
+				header.Set("Access-Control-Max-Age", "86400")
+			}
+
+			if c.Request().Method == http.MethodOptions {
+				return c.NoContent(http.StatusNoContent)
+			}
+			return next(c)
+		}
+	}
+}
+
+// {{.Var}}OriginAllowed reports whether the origin is listed in {{.Name}}AllowedOrigins
+func {{.Var}}OriginAllowed(origin string) bool {
+	for _, allowed := range {{.Name}}AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+`
+
+// RateLimitMiddlewareEchoTemplate - Template for a per-client token bucket rate limiter for echo
+const RateLimitMiddlewareEchoTemplate = `package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// {{.Var}}Bucket tracks the remaining tokens of one client
+type {{.Var}}Bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// {{.Name}}Middleware allows each client IP up to rps requests per second with bursts of up to burst requests
+func {{.Name}}Middleware(rps float64, burst int) echo.MiddlewareFunc {
+	var mu sync.Mutex
+	buckets := map[string]*{{.Var}}Bucket{}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			now := time.Now()
+			key := c.RealIP()
+
+			mu.Lock()
+			bucket, ok := buckets[key]
+			if !ok {
+				bucket = &{{.Var}}Bucket{tokens: float64(burst)}
+				buckets[key] = bucket
+			} else {
+				bucket.tokens += now.Sub(bucket.lastSeen).Seconds() * rps
+				if bucket.tokens > float64(burst) {
+					bucket.tokens = float64(burst)
+				}
+			}
+			bucket.lastSeen = now
+			allowed := bucket.tokens >= 1
+			if allowed {
+				bucket.tokens--
+			}
+
+			// Forget idle clients so the map does not grow without bound
+			for ip, b := range buckets {
+				if now.Sub(b.lastSeen) > 10*time.Minute {
+					delete(buckets, ip)
+				}
+			}
+			mu.Unlock()
+
+			if !allowed {
+				c.Response().Header().Set("Retry-After", strconv.Itoa(int(1/rps)+1))
+				return c.JSON(http.StatusTooManyRequests, echo.Map{"error": "rate limit exceeded"})
+			}
+			return next(c)
+		}
+	}
+}
+`
+
+// RequestIDMiddlewareEchoTemplate - Template for an echo middleware that assigns a request ID to every request
+const RequestIDMiddlewareEchoTemplate = `package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/labstack/echo/v4"
+)
+
+// {{.Name}}Header is the header carrying the request ID
+const {{.Name}}Header = "X-Request-ID"
+
+// {{.Name}}Middleware reuses the incoming request ID or generates a new one, and echoes it in the response
+func {{.Name}}Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id := c.Request().Header.Get({{.Name}}Header)
+			if id == "" {
+				buf := make([]byte, 16)
+				if _, err := rand.Read(buf); err == nil {
+					id = hex.EncodeToString(buf)
+				}
+			}
+
+			c.Set("request_id", id)
+			c.Response().Header().Set({{.Name}}Header, id)
+			return next(c)
+		}
+	}
+}
+`
+
+// LoggerMiddlewareEchoTemplate - Template for a structured request logging middleware for echo
+const LoggerMiddlewareEchoTemplate = `package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// {{.Name}}Middleware logs one line per request with its status and latency
+func {{.Name}}Middleware(logger *slog.Logger) echo.MiddlewareFunc {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			if err != nil {
+				// Let echo's error handler write the response so the logged status is the one sent
+				c.Error(err)
+			}
+
+			attrs := []any{
+				"method", c.Request().Method,
+				"path", c.Path(),
+				"status", c.Response().Status,
+				"latency", time.Since(start),
+				"client_ip", c.RealIP(),
+			}
+			if id := c.Get("request_id"); id != nil {
+				attrs = append(attrs, "request_id", id)
+			}
+			if err != nil {
+				logger.Error("request failed", append(attrs, "errors", err.Error())...)
+				return nil
+			}
+			logger.Info("request", attrs...)
+			return nil
+		}
+	}
+}
+`
+
+// RecoverMiddlewareEchoTemplate - Template for an echo middleware that turns panics into 500 responses
+const RecoverMiddlewareEchoTemplate = `package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/labstack/echo/v4"
+)
+
+// {{.Name}}Middleware recovers from panics in later handlers, logs the stack trace and responds with 500
+func {{.Name}}Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					slog.Error("panic recovered", "error", rec, "path", c.Request().URL.Path, "stack", string(debug.Stack()))
+					err = c.JSON(http.StatusInternalServerError, echo.Map{"error": "Internal Server Error"})
+				}
+			}()
+			return next(c)
+		}
+	}
+}
+`
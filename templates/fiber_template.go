@@ -0,0 +1,602 @@
+package templates
+
+// fiber_template.go - Templates for projects whose router is fiber (goi make --http fiber)
+
+// HandlerFiberTemplate - Template for generating fiber handlers
+const HandlerFiberTemplate = `package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"{{.Imports.DTO}}"        // Import the request DTOs
+	"{{.Imports.Services}}"   // Import the service layer
+	"github.com/go-playground/validator/v10" // Checks the DTO binding rules, as gin does
+	"github.com/gofiber/fiber/v2" // Import Fiber framework
+)
+
+// {{.HandlerName}}Handler handles requests for {{.HandlerName}} resources
+type {{.HandlerName}}Handler struct {
+	service  *services.{{.HandlerName}}Service
+	validate *validator.Validate
+}
+
+// New{{.HandlerName}}Handler creates a new instance of {{.HandlerName}}Handler
+func New{{.HandlerName}}Handler(service *services.{{.HandlerName}}Service) *{{.HandlerName}}Handler {
+	validate := validator.New()
+	validate.SetTagName("binding")
+	return &{{.HandlerName}}Handler{service: service, validate: validate}
+}
+
+// Index handles GET requests for {{.HandlerName}} resources
+func (h *{{.HandlerName}}Handler) Index(c *fiber.Ctx) error {
+	// Get all {{.HandlerName}} resources from the service
+	{{.VarPlural}}, err := h.service.GetAll()
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Failed to retrieve %s resources: %v", "{{.HandlerName}}", err)})
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{"data": {{.VarPlural}}})
+}
+
+// Show handles GET requests for a single {{.HandlerName}} resource by ID
+func (h *{{.HandlerName}}Handler) Show(c *fiber.Ctx) error {
+	// Get the ID from the URL parameters
+	id, err := h.parseID(c)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid ID: %v", err)})
+	}
+
+	// Fetch the resource by ID through the service
+	{{.Var}}, err := h.service.GetByID(id)
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%s resource not found: %v", "{{.HandlerName}}", err)})
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{"data": {{.Var}}})
+}
+
+// Create handles POST requests to create a new {{.HandlerName}} resource
+func (h *{{.HandlerName}}Handler) Create(c *fiber.Ctx) error {
+	var req dto.{{.HandlerName}}
+
+	// Bind and validate the request body against the {{.HandlerName}} DTO
+	if err := h.bind(c, &req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid input: %v", err)})
+	}
+
+	// Call the service to create the resource
+	created{{.HandlerName}}, err := h.service.Create(&req)
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Failed to create %s resource: %v", "{{.HandlerName}}", err)})
+	}
+	return c.Status(http.StatusCreated).JSON(fiber.Map{"data": created{{.HandlerName}}})
+}
+
+// Update handles PUT requests to update an existing {{.HandlerName}} resource
+func (h *{{.HandlerName}}Handler) Update(c *fiber.Ctx) error {
+	id, err := h.parseID(c)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid ID: %v", err)})
+	}
+	var req dto.{{.HandlerName}}
+
+	// Bind and validate the request body against the {{.HandlerName}} DTO
+	if err := h.bind(c, &req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid input: %v", err)})
+	}
+
+	// Update the resource through the service
+	updated{{.HandlerName}}, err := h.service.Update(id, &req)
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Failed to update %s resource: %v", "{{.HandlerName}}", err)})
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{"data": updated{{.HandlerName}}})
+}
+
+// Delete handles DELETE requests to remove a {{.HandlerName}} resource by ID
+func (h *{{.HandlerName}}Handler) Delete(c *fiber.Ctx) error {
+	id, err := h.parseID(c)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid ID: %v", err)})
+	}
+
+	// Delete the resource through the service
+	if err := h.service.Delete(id); err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Failed to delete %s resource: %v", "{{.HandlerName}}", err)})
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// bind decodes the JSON request body into req and checks its binding rules
+func (h *{{.HandlerName}}Handler) bind(c *fiber.Ctx, req *dto.{{.HandlerName}}) error {
+	if err := c.BodyParser(req); err != nil {
+		return err
+	}
+	return h.validate.Struct(req)
+}
+
+// parseID reads the numeric "id" URL parameter
+func (h *{{.HandlerName}}Handler) parseID(c *fiber.Ctx) (uint, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+`
+
+// SuccessResponseFiberTemplate - Template for generating SuccessResponse for fiber
+const SuccessResponseFiberTemplate = `package response
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SuccessResponse struct represents the structure of a success response
+type SuccessResponse struct {
+	Code    int         ` + "`" + `json:"code"` + "`" + `
+	Message string      ` + "`" + `json:"message"` + "`" + `
+	Data    interface{} ` + "`" + `json:"data,omitempty"` + "`" + `
+}
+
+// Success sends a standardized success response
+func Success(c *fiber.Ctx, data interface{}, message string) error {
+	return c.Status(http.StatusOK).JSON(SuccessResponse{
+		Code:    http.StatusOK,
+		Message: message,
+		Data:    data,
+	})
+}
+
+// NoContentResponse struct represents the structure of a no content response (204)
+type NoContentResponse struct {
+	Code    int    ` + "`" + `json:"code"` + "`" + `
+	Message string ` + "`" + `json:"message"` + "`" + `
+}
+
+// SendNoContent sends a standardized no content response
+func SendNoContent(c *fiber.Ctx, message string) error {
+	return c.Status(http.StatusNoContent).JSON(NoContentResponse{
+		Code:    http.StatusNoContent,
+		Message: message,
+	})
+}
+`
+
+// ErrorResponseFiberTemplate - Template for generating ErrorResponse for fiber
+const ErrorResponseFiberTemplate = `package response
+
+import (
+	"fmt" // Added for the InternalError function
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ErrorResponse struct {
+	Code    int         ` + "`" + `json:"code"` + "`" + `
+	Message string      ` + "`" + `json:"message"` + "`" + `
+	Errors  interface{} ` + "`" + `json:"errors,omitempty"` + "`" + `
+}
+
+func Error(c *fiber.Ctx, code int, message string, errors interface{}) error {
+	return c.Status(code).JSON(ErrorResponse{
+		Code:    code,
+		Message: message,
+		Errors:  errors,
+	})
+}
+
+func BadRequest(c *fiber.Ctx, message string, errors interface{}) error {
+	return Error(c, http.StatusBadRequest, message, errors)
+}
+
+func Unauthorized(c *fiber.Ctx, message string) error {
+	return Error(c, http.StatusUnauthorized, message, nil)
+}
+
+func InternalError(c *fiber.Ctx, err error) error {
+	return Error(c, http.StatusInternalServerError, "Internal Server Error", fmt.Sprintf("error: %v", err))
+}
+
+func ValidationError(c *fiber.Ctx, errs map[string]string) error {
+	return Error(c, http.StatusUnprocessableEntity, "Validation failed", errs)
+}
+
+func NotFoundResponse(c *fiber.Ctx, message string) error {
+	return Error(c, http.StatusNotFound, message, nil)
+}
+`
+
+// PaginationResponseFiberTemplate - Template for generating pagination response for fiber
+const PaginationResponseFiberTemplate = `package response
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Pagination struct {
+	Page       int         ` + "`" + `json:"page"` + "`" + `
+	Limit      int         ` + "`" + `json:"limit"` + "`" + `
+	TotalRows  int64       ` + "`" + `json:"total_rows"` + "`" + `
+	TotalPages int         ` + "`" + `json:"total_pages"` + "`" + `
+	Data       interface{} ` + "`" + `json:"data"` + "`" + `
+}
+
+func Paginated(c *fiber.Ctx, data interface{}, page, limit int, totalRows int64) error {
+	totalPages := int((totalRows + int64(limit) - 1) / int64(limit)) // ceil
+
+	return c.Status(http.StatusOK).JSON(Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalRows:  totalRows,
+		TotalPages: totalPages,
+		Data:       data,
+	})
+}
+`
+
+// RouteFiberTemplate - Template for generating the fiber router setup file that ` + "`goi make resource`" + ` registers routes in
+const RouteFiberTemplate = `package routes
+
+import (
+	{{if .Container}}"{{.Container}}"{{else}}"{{.DBImport}}"{{end}}
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupRoutes sets up the routes for the application
+func SetupRoutes(router *fiber.App, {{if .Container}}c *{{.ContainerPackage}}.Container{{else}}db {{.DBType}}{{end}}) {
+	// Resource routes are registered below by 'goi make resource'
+}
+`
+
+// HandlerTestFiberTemplate - Template for the CRUD handler tests of fiber handlers, backed by a fake repository
+const HandlerTestFiberTemplate = `package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+{{- if .NeedsTime}}
+	"time"
+{{- end}}
+
+	"{{.Imports.Models}}"
+	"{{.Imports.Services}}"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+` + handlerTestFakes + `// new{{.Name}}TestRouter routes the CRUD endpoints to a handler backed by repo.
+// The fiber app is adapted to net/http so the tests can record its responses with httptest.
+func new{{.Name}}TestRouter(repo *fake{{.Name}}Repository) http.Handler {
+	app := fiber.New()
+	h := New{{.Name}}Handler(services.New{{.Name}}Service(repo))
+	app.Get("/{{.KebabPlural}}", h.Index)
+	app.Get("/{{.KebabPlural}}/:id", h.Show)
+	app.Post("/{{.KebabPlural}}", h.Create)
+	app.Put("/{{.KebabPlural}}/:id", h.Update)
+	app.Delete("/{{.KebabPlural}}/:id", h.Delete)
+	return adaptor.FiberApp(app)
+}
+
+` + handlerTestCases
+
+// BlankMiddlewareFiberTemplate - Template for an empty fiber middleware skeleton
+const BlankMiddlewareFiberTemplate = `package middleware
+
+import "github.com/gofiber/fiber/v2"
+
+// {{.Name}}Middleware runs before the handlers of the routes it is attached to
+func {{.Name}}Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// Add your logic before the handler here
+
+		err := c.Next()
+
+		// Add your logic after the handler here
+		return err
+	}
+}
+`
+
+// JWTMiddlewareFiberTemplate - Template for a fiber middleware that verifies RS256 JWTs with the key generated by 'goi keys'
+const JWTMiddlewareFiberTemplate = `package middleware
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// {{.Name}}PublicKeyPath is the RSA public key generated by 'goi keys'
+const {{.Name}}PublicKeyPath = "config/rsa_public.pem"
+
+// {{.Name}}ClaimsKey is the fiber locals key holding the verified token claims
+const {{.Name}}ClaimsKey = "claims"
+
+// {{.Name}}Middleware rejects requests without a valid "Authorization: Bearer <token>" header.
+// Tokens must be signed with RS256 by the private key matching {{.Name}}PublicKeyPath.
+func {{.Name}}Middleware() fiber.Handler {
+	var (
+		mu        sync.Mutex
+		publicKey *rsa.PublicKey
+	)
+	// loadKey keeps the key once it has loaded, and retries a failed load on the next request
+	loadKey := func() (*rsa.PublicKey, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if publicKey == nil {
+			key, err := load{{.Name}}PublicKey({{.Name}}PublicKeyPath)
+			if err != nil {
+				return nil, err
+			}
+			publicKey = key
+		}
+		return publicKey, nil
+	}
+
+	return func(c *fiber.Ctx) error {
+		key, err := loadKey()
+		if err != nil {
+			return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": "authentication is not configured"})
+		}
+
+		header := c.Get("Authorization")
+		tokenString, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || tokenString == "" {
+			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "missing bearer token"})
+		}
+
+		claims := jwt.MapClaims{}
+		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+			return key, nil
+		}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+		if err != nil || !token.Valid {
+			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "invalid or expired token"})
+		}
+
+		c.Locals({{.Name}}ClaimsKey, claims)
+		return c.Next()
+	}
+}
+
+// load{{.Name}}PublicKey reads a PEM encoded RSA public key (PKIX or PKCS#1)
+func load{{.Name}}PublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key %s: %w", path, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("public key in %s is not an RSA key", path)
+		}
+		return rsaKey, nil
+	}
+	return x509.ParsePKCS1PublicKey(block.Bytes)
+}
+`
+
+// CORSMiddlewareFiberTemplate - Template for a fiber CORS middleware
+const CORSMiddlewareFiberTemplate = `package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// {{.Name}}AllowedOrigins lists the origins allowed to call the API; "*" allows any origin
+var {{.Name}}AllowedOrigins = []string{"*"}
+
+// {{.Name}}Middleware adds the CORS headers and answers preflight requests
+func {{.Name}}Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		origin := c.Get("Origin")
+		if origin != "" && {{.Var}}OriginAllowed(origin) {
+			c.Set("Access-Control-Allow-Origin", origin)
+			c.Set("Vary", "Origin")
+			c.Set("Access-Control-Allow-Credentials", "true")
+			c.Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+			c.Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-Request-ID")
+			c.Set("Access-Control-Max-Age", "86400")
+		}
+
+		if c.Method() == http.MethodOptions {
+			return c.SendStatus(http.StatusNoContent)
+		}
+		return c.Next()
+	}
+}
+
+// {{.Var}}OriginAllowed reports whether the origin is listed in {{.Name}}AllowedOrigins
+func {{.Var}}OriginAllowed(origin string) bool {
+	for _, allowed := range {{.Name}}AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+`
+
+// RateLimitMiddlewareFiberTemplate - Template for a per-client token bucket rate limiter for fiber
+const RateLimitMiddlewareFiberTemplate = `package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// {{.Var}}Bucket tracks the remaining tokens of one client
+type {{.Var}}Bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// {{.Name}}Middleware allows each client IP up to rps requests per second with bursts of up to burst requests
+func {{.Name}}Middleware(rps float64, burst int) fiber.Handler {
+	var mu sync.Mutex
+	buckets := map[string]*{{.Var}}Bucket{}
+
+	return func(c *fiber.Ctx) error {
+		now := time.Now()
+		key := c.IP()
+
+		mu.Lock()
+		bucket, ok := buckets[key]
+		if !ok {
+			bucket = &{{.Var}}Bucket{tokens: float64(burst)}
+			buckets[key] = bucket
+		} else {
+			bucket.tokens += now.Sub(bucket.lastSeen).Seconds() * rps
+			if bucket.tokens > float64(burst) {
+				bucket.tokens = float64(burst)
+			}
+		}
+		bucket.lastSeen = now
+		allowed := bucket.tokens >= 1
+		if allowed {
+			bucket.tokens--
+		}
+
+		// Forget idle clients so the map does not grow without bound
+		for ip, b := range buckets {
+			if now.Sub(b.lastSeen) > 10*time.Minute {
+				delete(buckets, ip)
+			}
+		}
+		mu.Unlock()
+
+		if !allowed {
+			c.Set("Retry-After", strconv.Itoa(int(1/rps)+1))
+			return c.Status(http.StatusTooManyRequests).JSON(fiber.Map{"error": "rate limit exceeded"})
+		}
+		return c.Next()
+	}
+}
+`
+
+// RequestIDMiddlewareFiberTemplate - Template for a fiber middleware that assigns a request ID to every request
+const RequestIDMiddlewareFiberTemplate = `package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// {{.Name}}Header is the header carrying the request ID
+const {{.Name}}Header = "X-Request-ID"
+
+// {{.Name}}Middleware reuses the incoming request ID or generates a new one, and echoes it in the response
+func {{.Name}}Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get({{.Name}}Header)
+		if id == "" {
+			buf := make([]byte, 16)
+			if _, err := rand.Read(buf); err == nil {
+				id = hex.EncodeToString(buf)
+			}
+		}
+
+		c.Locals("request_id", id)
+		c.Set({{.Name}}Header, id)
+		return c.Next()
+	}
+}
+`
+
+// LoggerMiddlewareFiberTemplate - Template for a structured request logging middleware for fiber
+const LoggerMiddlewareFiberTemplate = `package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// {{.Name}}Middleware logs one line per request with its status and latency
+func {{.Name}}Middleware(logger *slog.Logger) fiber.Handler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		attrs := []any{
+			"method", c.Method(),
+			"path", c.Route().Path,
+			"status", c.Response().StatusCode(),
+			"latency", time.Since(start),
+			"client_ip", c.IP(),
+		}
+		if id := c.Locals("request_id"); id != nil {
+			attrs = append(attrs, "request_id", id)
+		}
+		if err != nil {
+			logger.Error("request failed", append(attrs, "errors", err.Error())...)
+			return err
+		}
+		logger.Info("request", attrs...)
+		return nil
+	}
+}
+`
+
+// RecoverMiddlewareFiberTemplate - Template for a fiber middleware that turns panics into 500 responses
+const RecoverMiddlewareFiberTemplate = `package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// {{.Name}}Middleware recovers from panics in later handlers, logs the stack trace and responds with 500
+func {{.Name}}Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				slog.Error("panic recovered", "error", rec, "path", c.Path(), "stack", string(debug.Stack()))
+				err = c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": "Internal Server Error"})
+			}
+		}()
+		return c.Next()
+	}
+}
+`
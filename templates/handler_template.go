@@ -6,58 +6,63 @@ const HandlerTemplate = `package handlers
 import (
 	"fmt"
 	"net/http"
-	"{{.ModuleName}}/repository"  // Import the repository layer
-	"{{.ModuleName}}/models"      // Import your models (e.g., User)
+	"strconv"
+	"{{.Imports.DTO}}"        // Import the request DTOs
+	"{{.Imports.Services}}"   // Import the service layer
 	"github.com/gin-gonic/gin"    // Import Gin framework
 )
 
 // {{.HandlerName}}Handler handles requests for {{.HandlerName}} resources
 type {{.HandlerName}}Handler struct {
-	repo repository.{{.HandlerName}}Repository
+	service *services.{{.HandlerName}}Service
 }
 
 // New{{.HandlerName}}Handler creates a new instance of {{.HandlerName}}Handler
-func New{{.HandlerName}}Handler(repo repository.{{.HandlerName}}Repository) *{{.HandlerName}}Handler {
-	return &{{.HandlerName}}Handler{repo: repo}
+func New{{.HandlerName}}Handler(service *services.{{.HandlerName}}Service) *{{.HandlerName}}Handler {
+	return &{{.HandlerName}}Handler{service: service}
 }
 
 // Index handles GET requests for {{.HandlerName}} resources
 func (h *{{.HandlerName}}Handler) Index(c *gin.Context) {
-	// Get all {{.HandlerName}} resources from the repository
-	{{.HandlerName}}s, err := h.repo.GetAll()
+	// Get all {{.HandlerName}} resources from the service
+	{{.VarPlural}}, err := h.service.GetAll()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to retrieve %s resources: %v", "{{.HandlerName}}", err)})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"data": {{.HandlerName}}s})
+	c.JSON(http.StatusOK, gin.H{"data": {{.VarPlural}}})
 }
 
 // Show handles GET requests for a single {{.HandlerName}} resource by ID
 func (h *{{.HandlerName}}Handler) Show(c *gin.Context) {
 	// Get the ID from the URL parameters
-	id := c.Param("id")
+	id, err := h.parseID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid ID: %v", err)})
+		return
+	}
 
-	// Fetch the resource by ID from the repository
-	{{.HandlerName}}, err := h.repo.FindByID(id)
+	// Fetch the resource by ID through the service
+	{{.Var}}, err := h.service.GetByID(id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("%s resource not found: %v", "{{.HandlerName}}", err)})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"data": {{.HandlerName}}})
+	c.JSON(http.StatusOK, gin.H{"data": {{.Var}}})
 }
 
 // Create handles POST requests to create a new {{.HandlerName}} resource
 func (h *{{.HandlerName}}Handler) Create(c *gin.Context) {
-	var {{.HandlerName}} models.{{.HandlerName}}
+	var req dto.{{.HandlerName}}
 
-	// Bind the request body to the {{.HandlerName}} struct
-	if err := c.ShouldBindJSON(&{{.HandlerName}}); err != nil {
+	// Bind and validate the request body against the {{.HandlerName}} DTO
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid input: %v", err)})
 		return
 	}
 
-	// Call the repository to create the resource
-	created{{.HandlerName}}, err := h.repo.Create(&{{.HandlerName}})
+	// Call the service to create the resource
+	created{{.HandlerName}}, err := h.service.Create(&req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to create %s resource: %v", "{{.HandlerName}}", err)})
 		return
@@ -67,17 +72,21 @@ func (h *{{.HandlerName}}Handler) Create(c *gin.Context) {
 
 // Update handles PUT requests to update an existing {{.HandlerName}} resource
 func (h *{{.HandlerName}}Handler) Update(c *gin.Context) {
-	id := c.Param("id")
-	var {{.HandlerName}} models.{{.HandlerName}}
+	id, err := h.parseID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid ID: %v", err)})
+		return
+	}
+	var req dto.{{.HandlerName}}
 
-	// Bind the request body to the {{.HandlerName}} struct
-	if err := c.ShouldBindJSON(&{{.HandlerName}}); err != nil {
+	// Bind and validate the request body against the {{.HandlerName}} DTO
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid input: %v", err)})
 		return
 	}
 
-	// Update the resource in the repository
-	updated{{.HandlerName}}, err := h.repo.UpdateByID(id, &{{.HandlerName}})
+	// Update the resource through the service
+	updated{{.HandlerName}}, err := h.service.Update(id, &req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to update %s resource: %v", "{{.HandlerName}}", err)})
 		return
@@ -87,13 +96,26 @@ func (h *{{.HandlerName}}Handler) Update(c *gin.Context) {
 
 // Delete handles DELETE requests to remove a {{.HandlerName}} resource by ID
 func (h *{{.HandlerName}}Handler) Delete(c *gin.Context) {
-	id := c.Param("id")
+	id, err := h.parseID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid ID: %v", err)})
+		return
+	}
 
-	// Delete the resource from the repository
-	if err := h.repo.DeleteOne(id); err != nil {
+	// Delete the resource through the service
+	if err := h.service.Delete(id); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to delete %s resource: %v", "{{.HandlerName}}", err)})
 		return
 	}
-	c.JSON(http.StatusNoContent, nil)
+	c.Status(http.StatusNoContent)
+}
+
+// parseID reads the numeric "id" URL parameter
+func (h *{{.HandlerName}}Handler) parseID(c *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
 }
 `
@@ -0,0 +1,317 @@
+package templates
+
+// middleware_template.go - Templates for generating gin middleware (goi make middleware <name> --kind <kind>)
+
+// BlankMiddlewareTemplate - Template for an empty middleware skeleton
+const BlankMiddlewareTemplate = `package middleware
+
+import "github.com/gin-gonic/gin"
+
+// {{.Name}}Middleware runs before the handlers of the routes it is attached to
+func {{.Name}}Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Add your logic before the handler here
+
+		c.Next()
+
+		// Add your logic after the handler here
+	}
+}
+`
+
+// JWTMiddlewareTemplate - Template for a middleware that verifies RS256 JWTs with the key generated by 'goi keys'
+const JWTMiddlewareTemplate = `package middleware
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// {{.Name}}PublicKeyPath is the RSA public key generated by 'goi keys'
+const {{.Name}}PublicKeyPath = "config/rsa_public.pem"
+
+// {{.Name}}ClaimsKey is the gin context key holding the verified token claims
+const {{.Name}}ClaimsKey = "claims"
+
+// {{.Name}}Middleware rejects requests without a valid "Authorization: Bearer <token>" header.
+// Tokens must be signed with RS256 by the private key matching {{.Name}}PublicKeyPath.
+func {{.Name}}Middleware() gin.HandlerFunc {
+	var (
+		mu        sync.Mutex
+		publicKey *rsa.PublicKey
+	)
+	// loadKey keeps the key once it has loaded, and retries a failed load on the next request
+	loadKey := func() (*rsa.PublicKey, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if publicKey == nil {
+			key, err := load{{.Name}}PublicKey({{.Name}}PublicKeyPath)
+			if err != nil {
+				return nil, err
+			}
+			publicKey = key
+		}
+		return publicKey, nil
+	}
+
+	return func(c *gin.Context) {
+		key, err := loadKey()
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "authentication is not configured"})
+			return
+		}
+
+		header := c.GetHeader("Authorization")
+		tokenString, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || tokenString == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
+			return
+		}
+
+		claims := jwt.MapClaims{}
+		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+			return key, nil
+		}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+		if err != nil || !token.Valid {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+			return
+		}
+
+		c.Set({{.Name}}ClaimsKey, claims)
+		c.Next()
+	}
+}
+
+// load{{.Name}}PublicKey reads a PEM encoded RSA public key (PKIX or PKCS#1)
+func load{{.Name}}PublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key %s: %w", path, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("public key in %s is not an RSA key", path)
+		}
+		return rsaKey, nil
+	}
+	return x509.ParsePKCS1PublicKey(block.Bytes)
+}
+`
+
+// CORSMiddlewareTemplate - Template for a CORS middleware
+const CORSMiddlewareTemplate = `package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// {{.Name}}AllowedOrigins lists the origins allowed to call the API; "*" allows any origin
+var {{.Name}}AllowedOrigins = []string{"*"}
+
+// {{.Name}}Middleware adds the CORS headers and answers preflight requests
+func {{.Name}}Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin != "" && {{.Var}}OriginAllowed(origin) {
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Vary", "Origin")
+			c.Header("Access-Control-Allow-Credentials", "true")
+			c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+			c.Header("Access-Control-Allow-Headers", "Authorization, Content-Type, X-Request-ID")
+			c.Header("Access-Control-Max-Age", "86400")
+		}
+
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
+
+// {{.Var}}OriginAllowed reports whether the origin is listed in {{.Name}}AllowedOrigins
+func {{.Var}}OriginAllowed(origin string) bool {
+	for _, allowed := range {{.Name}}AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+`
+
+// RateLimitMiddlewareTemplate - Template for a per-client token bucket rate limiter
+const RateLimitMiddlewareTemplate = `package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// {{.Var}}Bucket tracks the remaining tokens of one client
+type {{.Var}}Bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// {{.Name}}Middleware allows each client IP up to rps requests per second with bursts of up to burst requests
+func {{.Name}}Middleware(rps float64, burst int) gin.HandlerFunc {
+	var mu sync.Mutex
+	buckets := map[string]*{{.Var}}Bucket{}
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		key := c.ClientIP()
+
+		mu.Lock()
+		bucket, ok := buckets[key]
+		if !ok {
+			bucket = &{{.Var}}Bucket{tokens: float64(burst)}
+			buckets[key] = bucket
+		} else {
+			bucket.tokens += now.Sub(bucket.lastSeen).Seconds() * rps
+			if bucket.tokens > float64(burst) {
+				bucket.tokens = float64(burst)
+			}
+		}
+		bucket.lastSeen = now
+		allowed := bucket.tokens >= 1
+		if allowed {
+			bucket.tokens--
+		}
+
+		// Forget idle clients so the map does not grow without bound
+		for ip, b := range buckets {
+			if now.Sub(b.lastSeen) > 10*time.Minute {
+				delete(buckets, ip)
+			}
+		}
+		mu.Unlock()
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(1/rps)+1))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
+`
+
+// RequestIDMiddlewareTemplate - Template for a middleware that assigns a request ID to every request
+const RequestIDMiddlewareTemplate = `package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// {{.Name}}Header is the header carrying the request ID
+const {{.Name}}Header = "X-Request-ID"
+
+// {{.Name}}Middleware reuses the incoming request ID or generates a new one, and echoes it in the response
+func {{.Name}}Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader({{.Name}}Header)
+		if id == "" {
+			buf := make([]byte, 16)
+			if _, err := rand.Read(buf); err == nil {
+				id = hex.EncodeToString(buf)
+			}
+		}
+
+		c.Set("request_id", id)
+		c.Header({{.Name}}Header, id)
+		c.Next()
+	}
+}
+`
+
+// LoggerMiddlewareTemplate - Template for a structured request logging middleware
+const LoggerMiddlewareTemplate = `package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// {{.Name}}Middleware logs one line per request with its status and latency
+func {{.Name}}Middleware(logger *slog.Logger) gin.HandlerFunc {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		attrs := []any{
+			"method", c.Request.Method,
+			"path", c.FullPath(),
+			"status", c.Writer.Status(),
+			"latency", time.Since(start),
+			"client_ip", c.ClientIP(),
+		}
+		if id, ok := c.Get("request_id"); ok {
+			attrs = append(attrs, "request_id", id)
+		}
+		if len(c.Errors) > 0 {
+			logger.Error("request failed", append(attrs, "errors", c.Errors.String())...)
+			return
+		}
+		logger.Info("request", attrs...)
+	}
+}
+`
+
+// RecoverMiddlewareTemplate - Template for a middleware that turns panics into 500 responses
+const RecoverMiddlewareTemplate = `package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+)
+
+// {{.Name}}Middleware recovers from panics in later handlers, logs the stack trace and responds with 500
+func {{.Name}}Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				slog.Error("panic recovered", "error", rec, "path", c.Request.URL.Path, "stack", string(debug.Stack()))
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
+			}
+		}()
+		c.Next()
+	}
+}
+`
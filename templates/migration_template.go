@@ -0,0 +1,59 @@
+package templates
+
+// migration_template.go - Templates for generating SQL migrations (goi make migration <name>)
+
+// MigrationUpTemplate - Template for the up migration; creates the table when fields are given,
+// in the MySQL or PostgreSQL dialect
+const MigrationUpTemplate = `-- {{.Name}} (up)
+{{- if and .Fields (eq .Dialect "postgres")}}
+CREATE TABLE IF NOT EXISTS "{{.Table}}" (
+  "id" BIGSERIAL PRIMARY KEY,
+{{- range .Fields}}
+  "{{.ColumnName}}" {{.PGType}}{{if .Required}} NOT NULL{{else}} NULL{{end}},
+{{- end}}
+  "created_at" TIMESTAMPTZ NULL,
+  "updated_at" TIMESTAMPTZ NULL,
+  "deleted_at" TIMESTAMPTZ NULL
+);
+{{- range .Fields}}
+{{- if .Unique}}
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_{{$.Table}}_{{.ColumnName}}" ON "{{$.Table}}" ("{{.ColumnName}}");
+{{- else if .Index}}
+CREATE INDEX IF NOT EXISTS "idx_{{$.Table}}_{{.ColumnName}}" ON "{{$.Table}}" ("{{.ColumnName}}");
+{{- end}}
+{{- end}}
+CREATE INDEX IF NOT EXISTS "idx_{{.Table}}_deleted_at" ON "{{.Table}}" ("deleted_at");
+{{- else if .Fields}}
+CREATE TABLE IF NOT EXISTS ` + "`" + `{{.Table}}` + "`" + ` (
+  ` + "`" + `id` + "`" + ` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+{{- range .Fields}}
+  ` + "`" + `{{.ColumnName}}` + "`" + ` {{.SQLType}}{{if .Required}} NOT NULL{{else}} NULL{{end}},
+{{- end}}
+  ` + "`" + `created_at` + "`" + ` DATETIME(3) NULL,
+  ` + "`" + `updated_at` + "`" + ` DATETIME(3) NULL,
+  ` + "`" + `deleted_at` + "`" + ` DATETIME(3) NULL,
+  PRIMARY KEY (` + "`" + `id` + "`" + `),
+{{- range .Fields}}
+{{- if .Unique}}
+  UNIQUE KEY ` + "`" + `idx_{{$.Table}}_{{.ColumnName}}` + "`" + ` (` + "`" + `{{.ColumnName}}` + "`" + `{{if eq .SQLType "TEXT"}}(255){{end}}),
+{{- else if .Index}}
+  KEY ` + "`" + `idx_{{$.Table}}_{{.ColumnName}}` + "`" + ` (` + "`" + `{{.ColumnName}}` + "`" + `{{if eq .SQLType "TEXT"}}(255){{end}}),
+{{- end}}
+{{- end}}
+  KEY ` + "`" + `idx_{{.Table}}_deleted_at` + "`" + ` (` + "`" + `deleted_at` + "`" + `)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+{{- else}}
+-- Write the SQL that applies this migration here
+{{- end}}
+`
+
+// MigrationDownTemplate - Template for the down migration; drops the table when fields are given
+const MigrationDownTemplate = `-- {{.Name}} (down)
+{{- if and .Fields (eq .Dialect "postgres")}}
+DROP TABLE IF EXISTS "{{.Table}}";
+{{- else if .Fields}}
+DROP TABLE IF EXISTS ` + "`" + `{{.Table}}` + "`" + `;
+{{- else}}
+-- Write the SQL that reverts this migration here
+{{- end}}
+`
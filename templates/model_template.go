@@ -2,16 +2,35 @@ package templates
 
 // model_template.go - Template for generating models
 const ModelTemplate = `package models
+{{- if or .NeedsTime .BaseTime .NeedsGorm}}
 
-import "gorm.io/gorm"
+import (
+{{- if or .NeedsTime .BaseTime}}
+    "time"
+
+{{- end}}
+{{- if .NeedsGorm}}
+    "gorm.io/gorm"
+{{- end}}
+)
+{{- end}}
 
 // {{.ModelName}} represents the {{.ModelName}} model in the database
 type {{.ModelName}} struct {
+{{- range .BaseFields}}
+    {{.FieldName}} {{.FieldType}} ` + "`" + `gorm:"{{.GormTag}}" json:"{{.JsonTag}}"` + "`" + `
+{{- else}}
     gorm.Model
-    Name        string ` + "`" + `json:"name"` + "`" + `
-    Description string ` + "`" + `json:"description,omitempty"` + "`" + `
-    IsActive    bool   ` + "`" + `json:"is_active"` + "`" + `
-    Count       int    ` + "`" + `json:"count"` + "`" + `
+{{- end}}
+{{- range .Fields}}
+    {{.FieldName}} {{.FieldType}} ` + "`" + `gorm:"{{.GormTag}}" json:"{{.JsonTag}}"` + "`" + `
+{{- else}}
     // Define fields here
+{{- end}}
+}
+
+// TableName returns the database table that stores {{.ModelName}} records
+func ({{.ModelName}}) TableName() string {
+    return "{{.Table}}"
 }
 `
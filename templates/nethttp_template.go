@@ -0,0 +1,692 @@
+package templates
+
+// nethttp_template.go - Templates for projects whose handlers are plain net/http handlers, routed by chi or
+// by the standard library's ServeMux (goi make --http chi|stdlib). They branch on .HTTP.Name where the routers differ.
+
+// HandlerNetHTTPTemplate - Template for generating net/http handlers
+const HandlerNetHTTPTemplate = `package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"{{.Imports.DTO}}"        // Import the request DTOs
+	"{{.Imports.Services}}"   // Import the service layer
+	"github.com/go-playground/validator/v10" // Checks the DTO binding rules, as gin does
+{{- if eq .HTTP.Name "chi"}}
+	"github.com/go-chi/chi/v5"    // Import chi for URL parameters
+{{- end}}
+)
+
+// {{.HandlerName}}Handler handles requests for {{.HandlerName}} resources
+type {{.HandlerName}}Handler struct {
+	service  *services.{{.HandlerName}}Service
+	validate *validator.Validate
+}
+
+// New{{.HandlerName}}Handler creates a new instance of {{.HandlerName}}Handler
+func New{{.HandlerName}}Handler(service *services.{{.HandlerName}}Service) *{{.HandlerName}}Handler {
+	validate := validator.New()
+	validate.SetTagName("binding")
+	return &{{.HandlerName}}Handler{service: service, validate: validate}
+}
+
+// Index handles GET requests for {{.HandlerName}} resources
+func (h *{{.HandlerName}}Handler) Index(w http.ResponseWriter, r *http.Request) {
+	// Get all {{.HandlerName}} resources from the service
+	{{.VarPlural}}, err := h.service.GetAll()
+	if err != nil {
+		h.writeJSON(w, http.StatusInternalServerError, map[string]interface{}{"error": fmt.Sprintf("Failed to retrieve %s resources: %v", "{{.HandlerName}}", err)})
+		return
+	}
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{"data": {{.VarPlural}}})
+}
+
+// Show handles GET requests for a single {{.HandlerName}} resource by ID
+func (h *{{.HandlerName}}Handler) Show(w http.ResponseWriter, r *http.Request) {
+	// Get the ID from the URL parameters
+	id, err := h.parseID(r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": fmt.Sprintf("Invalid ID: %v", err)})
+		return
+	}
+
+	// Fetch the resource by ID through the service
+	{{.Var}}, err := h.service.GetByID(id)
+	if err != nil {
+		h.writeJSON(w, http.StatusNotFound, map[string]interface{}{"error": fmt.Sprintf("%s resource not found: %v", "{{.HandlerName}}", err)})
+		return
+	}
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{"data": {{.Var}}})
+}
+
+// Create handles POST requests to create a new {{.HandlerName}} resource
+func (h *{{.HandlerName}}Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.{{.HandlerName}}
+
+	// Bind and validate the request body against the {{.HandlerName}} DTO
+	if err := h.bind(r, &req); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": fmt.Sprintf("Invalid input: %v", err)})
+		return
+	}
+
+	// Call the service to create the resource
+	created{{.HandlerName}}, err := h.service.Create(&req)
+	if err != nil {
+		h.writeJSON(w, http.StatusInternalServerError, map[string]interface{}{"error": fmt.Sprintf("Failed to create %s resource: %v", "{{.HandlerName}}", err)})
+		return
+	}
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{"data": created{{.HandlerName}}})
+}
+
+// Update handles PUT requests to update an existing {{.HandlerName}} resource
+func (h *{{.HandlerName}}Handler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := h.parseID(r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": fmt.Sprintf("Invalid ID: %v", err)})
+		return
+	}
+	var req dto.{{.HandlerName}}
+
+	// Bind and validate the request body against the {{.HandlerName}} DTO
+	if err := h.bind(r, &req); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": fmt.Sprintf("Invalid input: %v", err)})
+		return
+	}
+
+	// Update the resource through the service
+	updated{{.HandlerName}}, err := h.service.Update(id, &req)
+	if err != nil {
+		h.writeJSON(w, http.StatusInternalServerError, map[string]interface{}{"error": fmt.Sprintf("Failed to update %s resource: %v", "{{.HandlerName}}", err)})
+		return
+	}
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{"data": updated{{.HandlerName}}})
+}
+
+// Delete handles DELETE requests to remove a {{.HandlerName}} resource by ID
+func (h *{{.HandlerName}}Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := h.parseID(r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": fmt.Sprintf("Invalid ID: %v", err)})
+		return
+	}
+
+	// Delete the resource through the service
+	if err := h.service.Delete(id); err != nil {
+		h.writeJSON(w, http.StatusInternalServerError, map[string]interface{}{"error": fmt.Sprintf("Failed to delete %s resource: %v", "{{.HandlerName}}", err)})
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// bind decodes the JSON request body into req and checks its binding rules
+func (h *{{.HandlerName}}Handler) bind(r *http.Request, req *dto.{{.HandlerName}}) error {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return err
+	}
+	return h.validate.Struct(req)
+}
+
+// writeJSON sends v encoded as JSON with the given status code
+func (h *{{.HandlerName}}Handler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// parseID reads the numeric "id" URL parameter
+func (h *{{.HandlerName}}Handler) parseID(r *http.Request) (uint, error) {
+	id, err := strconv.ParseUint({{if eq .HTTP.Name "chi"}}chi.URLParam(r, "id"){{else}}r.PathValue("id"){{end}}, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+`
+
+// SuccessResponseNetHTTPTemplate - Template for generating SuccessResponse for net/http handlers
+const SuccessResponseNetHTTPTemplate = `package response
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// SuccessResponse struct represents the structure of a success response
+type SuccessResponse struct {
+	Code    int         ` + "`" + `json:"code"` + "`" + `
+	Message string      ` + "`" + `json:"message"` + "`" + `
+	Data    interface{} ` + "`" + `json:"data,omitempty"` + "`" + `
+}
+
+// JSON sends v encoded as JSON with the given status code
+func JSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// Success sends a standardized success response
+func Success(w http.ResponseWriter, data interface{}, message string) {
+	JSON(w, http.StatusOK, SuccessResponse{
+		Code:    http.StatusOK,
+		Message: message,
+		Data:    data,
+	})
+}
+
+// NoContentResponse struct represents the structure of a no content response (204)
+type NoContentResponse struct {
+	Code    int    ` + "`" + `json:"code"` + "`" + `
+	Message string ` + "`" + `json:"message"` + "`" + `
+}
+
+// SendNoContent sends a standardized no content response
+func SendNoContent(w http.ResponseWriter, message string) {
+	JSON(w, http.StatusNoContent, NoContentResponse{
+		Code:    http.StatusNoContent,
+		Message: message,
+	})
+}
+`
+
+// ErrorResponseNetHTTPTemplate - Template for generating ErrorResponse for net/http handlers
+const ErrorResponseNetHTTPTemplate = `package response
+
+import (
+	"fmt" // Added for the InternalError function
+	"net/http"
+)
+
+type ErrorResponse struct {
+	Code    int         ` + "`" + `json:"code"` + "`" + `
+	Message string      ` + "`" + `json:"message"` + "`" + `
+	Errors  interface{} ` + "`" + `json:"errors,omitempty"` + "`" + `
+}
+
+func Error(w http.ResponseWriter, code int, message string, errors interface{}) {
+	JSON(w, code, ErrorResponse{
+		Code:    code,
+		Message: message,
+		Errors:  errors,
+	})
+}
+
+func BadRequest(w http.ResponseWriter, message string, errors interface{}) {
+	Error(w, http.StatusBadRequest, message, errors)
+}
+
+func Unauthorized(w http.ResponseWriter, message string) {
+	Error(w, http.StatusUnauthorized, message, nil)
+}
+
+func InternalError(w http.ResponseWriter, err error) {
+	Error(w, http.StatusInternalServerError, "Internal Server Error", fmt.Sprintf("error: %v", err))
+}
+
+func ValidationError(w http.ResponseWriter, errs map[string]string) {
+	Error(w, http.StatusUnprocessableEntity, "Validation failed", errs)
+}
+
+func NotFoundResponse(w http.ResponseWriter, message string) {
+	Error(w, http.StatusNotFound, message, nil)
+}
+`
+
+// PaginationResponseNetHTTPTemplate - Template for generating pagination response for net/http handlers
+const PaginationResponseNetHTTPTemplate = `package response
+
+import (
+	"net/http"
+)
+
+type Pagination struct {
+	Page       int         ` + "`" + `json:"page"` + "`" + `
+	Limit      int         ` + "`" + `json:"limit"` + "`" + `
+	TotalRows  int64       ` + "`" + `json:"total_rows"` + "`" + `
+	TotalPages int         ` + "`" + `json:"total_pages"` + "`" + `
+	Data       interface{} ` + "`" + `json:"data"` + "`" + `
+}
+
+func Paginated(w http.ResponseWriter, data interface{}, page, limit int, totalRows int64) {
+	totalPages := int((totalRows + int64(limit) - 1) / int64(limit)) // ceil
+
+	JSON(w, http.StatusOK, Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalRows:  totalRows,
+		TotalPages: totalPages,
+		Data:       data,
+	})
+}
+`
+
+// RouteNetHTTPTemplate - Template for generating the chi or ServeMux router setup file that ` + "`goi make resource`" + ` registers routes in
+const RouteNetHTTPTemplate = `package routes
+
+import (
+{{- if eq .HTTP.Name "chi"}}
+	"github.com/go-chi/chi/v5"
+{{- else}}
+	"net/http"
+{{end}}
+	{{if .Container}}"{{.Container}}"{{else}}"{{.DBImport}}"{{end}}
+)
+
+// SetupRoutes sets up the routes for the application
+func SetupRoutes({{if eq .HTTP.Name "chi"}}r chi.Router{{else}}mux *http.ServeMux{{end}}, {{if .Container}}c *{{.ContainerPackage}}.Container{{else}}db {{.DBType}}{{end}}) {
+	// Resource routes are registered below by 'goi make resource'
+}
+`
+
+// HandlerTestNetHTTPTemplate - Template for the CRUD handler tests of net/http handlers, backed by a fake repository
+const HandlerTestNetHTTPTemplate = `package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+{{- if .NeedsTime}}
+	"time"
+{{- end}}
+
+	"{{.Imports.Models}}"
+	"{{.Imports.Services}}"
+{{- if eq .HTTP.Name "chi"}}
+	"github.com/go-chi/chi/v5"
+{{- end}}
+)
+
+` + handlerTestFakes + `// new{{.Name}}TestRouter routes the CRUD endpoints to a handler backed by repo
+func new{{.Name}}TestRouter(repo *fake{{.Name}}Repository) http.Handler {
+	h := New{{.Name}}Handler(services.New{{.Name}}Service(repo))
+{{- if eq .HTTP.Name "chi"}}
+	r := chi.NewRouter()
+	r.Get("/{{.KebabPlural}}", h.Index)
+	r.Get("/{{.KebabPlural}}/{id}", h.Show)
+	r.Post("/{{.KebabPlural}}", h.Create)
+	r.Put("/{{.KebabPlural}}/{id}", h.Update)
+	r.Delete("/{{.KebabPlural}}/{id}", h.Delete)
+	return r
+{{- else}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{{.KebabPlural}}", h.Index)
+	mux.HandleFunc("GET /{{.KebabPlural}}/{id}", h.Show)
+	mux.HandleFunc("POST /{{.KebabPlural}}", h.Create)
+	mux.HandleFunc("PUT /{{.KebabPlural}}/{id}", h.Update)
+	mux.HandleFunc("DELETE /{{.KebabPlural}}/{id}", h.Delete)
+	return mux
+{{- end}}
+}
+
+` + handlerTestCases
+
+// BlankMiddlewareNetHTTPTemplate - Template for an empty net/http middleware skeleton
+const BlankMiddlewareNetHTTPTemplate = `package middleware
+
+import "net/http"
+
+// {{.Name}}Middleware runs before the handlers of the routes it is attached to
+func {{.Name}}Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Add your logic before the handler here
+
+			next.ServeHTTP(w, r)
+
+			// Add your logic after the handler here
+		})
+	}
+}
+`
+
+// JWTMiddlewareNetHTTPTemplate - Template for a net/http middleware that verifies RS256 JWTs with the key generated by 'goi keys'
+const JWTMiddlewareNetHTTPTemplate = `package middleware
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// {{.Name}}PublicKeyPath is the RSA public key generated by 'goi keys'
+const {{.Name}}PublicKeyPath = "config/rsa_public.pem"
+
+// {{.Var}}ContextKey is the type of the request context keys set by {{.Name}}Middleware
+type {{.Var}}ContextKey string
+
+// {{.Name}}ClaimsKey is the request context key holding the verified token claims
+const {{.Name}}ClaimsKey {{.Var}}ContextKey = "claims"
+
+// {{.Name}}Middleware rejects requests without a valid "Authorization: Bearer <token>" header.
+// Tokens must be signed with RS256 by the private key matching {{.Name}}PublicKeyPath.
+func {{.Name}}Middleware() func(http.Handler) http.Handler {
+	var (
+		mu        sync.Mutex
+		publicKey *rsa.PublicKey
+	)
+	// loadKey keeps the key once it has loaded, and retries a failed load on the next request
+	loadKey := func() (*rsa.PublicKey, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if publicKey == nil {
+			key, err := load{{.Name}}PublicKey({{.Name}}PublicKeyPath)
+			if err != nil {
+				return nil, err
+			}
+			publicKey = key
+		}
+		return publicKey, nil
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, err := loadKey()
+			if err != nil {
+				{{.Var}}Error(w, http.StatusInternalServerError, "authentication is not configured")
+				return
+			}
+
+			header := r.Header.Get("Authorization")
+			tokenString, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || tokenString == "" {
+				{{.Var}}Error(w, http.StatusUnauthorized, "missing bearer token")
+				return
+			}
+
+			claims := jwt.MapClaims{}
+			token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+				return key, nil
+			}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+			if err != nil || !token.Valid {
+				{{.Var}}Error(w, http.StatusUnauthorized, "invalid or expired token")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), {{.Name}}ClaimsKey, claims)))
+		})
+	}
+}
+
+// {{.Var}}Error sends a JSON error body with the given status code
+func {{.Var}}Error(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// load{{.Name}}PublicKey reads a PEM encoded RSA public key (PKIX or PKCS#1)
+func load{{.Name}}PublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key %s: %w", path, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("public key in %s is not an RSA key", path)
+		}
+		return rsaKey, nil
+	}
+	return x509.ParsePKCS1PublicKey(block.Bytes)
+}
+`
+
+// CORSMiddlewareNetHTTPTemplate - Template for a net/http CORS middleware
+const CORSMiddlewareNetHTTPTemplate = `package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// {{.Name}}AllowedOrigins lists the origins allowed to call the API; "*" allows any origin
+var {{.Name}}AllowedOrigins = []string{"*"}
+
+// {{.Name}}Middleware adds the CORS headers and answers preflight requests
+func {{.Name}}Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin != "" && {{.Var}}OriginAllowed(origin) {
+				header := w.Header()
+				header.Set("Access-Control-Allow-Origin", origin)
+				header.Set("Vary", "Origin")
+				header.Set("Access-Control-Allow-Credentials", "true")
+				header.Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+				header.Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-Request-ID")
+				header.Set("Access-Control-Max-Age", "86400")
+			}
+
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// {{.Var}}OriginAllowed reports whether the origin is listed in {{.Name}}AllowedOrigins
+func {{.Var}}OriginAllowed(origin string) bool {
+	for _, allowed := range {{.Name}}AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+`
+
+// RateLimitMiddlewareNetHTTPTemplate - Template for a per-client token bucket rate limiter for net/http
+const RateLimitMiddlewareNetHTTPTemplate = `package middleware
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// {{.Var}}Bucket tracks the remaining tokens of one client
+type {{.Var}}Bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// {{.Name}}Middleware allows each client IP up to rps requests per second with bursts of up to burst requests
+func {{.Name}}Middleware(rps float64, burst int) func(http.Handler) http.Handler {
+	var mu sync.Mutex
+	buckets := map[string]*{{.Var}}Bucket{}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			now := time.Now()
+			key, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				key = r.RemoteAddr
+			}
+
+			mu.Lock()
+			bucket, ok := buckets[key]
+			if !ok {
+				bucket = &{{.Var}}Bucket{tokens: float64(burst)}
+				buckets[key] = bucket
+			} else {
+				bucket.tokens += now.Sub(bucket.lastSeen).Seconds() * rps
+				if bucket.tokens > float64(burst) {
+					bucket.tokens = float64(burst)
+				}
+			}
+			bucket.lastSeen = now
+			allowed := bucket.tokens >= 1
+			if allowed {
+				bucket.tokens--
+			}
+
+			// Forget idle clients so the map does not grow without bound
+			for ip, b := range buckets {
+				if now.Sub(b.lastSeen) > 10*time.Minute {
+					delete(buckets, ip)
+				}
+			}
+			mu.Unlock()
+
+			if !allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(int(1/rps)+1))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusTooManyRequests)
+				json.NewEncoder(w).Encode(map[string]string{"error": "rate limit exceeded"})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+`
+
+// RequestIDMiddlewareNetHTTPTemplate - Template for a net/http middleware that assigns a request ID to every request
+const RequestIDMiddlewareNetHTTPTemplate = `package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// {{.Name}}Header is the header carrying the request ID
+const {{.Name}}Header = "X-Request-ID"
+
+// {{.Var}}ContextKey is the type of the request context key set by {{.Name}}Middleware
+type {{.Var}}ContextKey struct{}
+
+// {{.Name}}Middleware reuses the incoming request ID or generates a new one, and echoes it in the response
+func {{.Name}}Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get({{.Name}}Header)
+			if id == "" {
+				buf := make([]byte, 16)
+				if _, err := rand.Read(buf); err == nil {
+					id = hex.EncodeToString(buf)
+				}
+			}
+
+			w.Header().Set({{.Name}}Header, id)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), {{.Var}}ContextKey{}, id)))
+		})
+	}
+}
+
+// {{.Name}}FromContext returns the request ID stored by {{.Name}}Middleware, or "" if there is none
+func {{.Name}}FromContext(ctx context.Context) string {
+	id, _ := ctx.Value({{.Var}}ContextKey{}).(string)
+	return id
+}
+`
+
+// LoggerMiddlewareNetHTTPTemplate - Template for a structured request logging middleware for net/http
+const LoggerMiddlewareNetHTTPTemplate = `package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// {{.Var}}StatusRecorder remembers the status code written by the handler
+type {{.Var}}StatusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code before sending it
+func (r *{{.Var}}StatusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// {{.Name}}Middleware logs one line per request with its status and latency
+func {{.Name}}Middleware(logger *slog.Logger) func(http.Handler) http.Handler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := &{{.Var}}StatusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+
+			attrs := []any{
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", recorder.status,
+				"latency", time.Since(start),
+				"client_ip", r.RemoteAddr,
+			}
+			// A request ID middleware echoes the ID in the response headers
+			if id := w.Header().Get("X-Request-ID"); id != "" {
+				attrs = append(attrs, "request_id", id)
+			}
+			if recorder.status >= http.StatusInternalServerError {
+				logger.Error("request failed", attrs...)
+				return
+			}
+			logger.Info("request", attrs...)
+		})
+	}
+}
+`
+
+// RecoverMiddlewareNetHTTPTemplate - Template for a net/http middleware that turns panics into 500 responses
+const RecoverMiddlewareNetHTTPTemplate = `package middleware
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+// {{.Name}}Middleware recovers from panics in later handlers, logs the stack trace and responds with 500
+func {{.Name}}Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					slog.Error("panic recovered", "error", rec, "path", r.URL.Path, "stack", string(debug.Stack()))
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusInternalServerError)
+					json.NewEncoder(w).Encode(map[string]string{"error": "Internal Server Error"})
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+`
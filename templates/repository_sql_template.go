@@ -0,0 +1,824 @@
+package templates
+
+// repository_sql_template.go - Templates for the models and repositories of the backends that write SQL by hand
+// (sqlx, pgx and database/sql). They implement the same repository interface as the GORM template.
+
+// ModelSQLTemplate - Template for models mapped with db tags instead of gorm.Model
+const ModelSQLTemplate = `package models
+{{- if or .NeedsTime .BaseTime}}
+
+import "time"
+{{- end}}
+
+// {{.ModelName}} represents the {{.ModelName}} model in the database
+type {{.ModelName}} struct {
+{{- range .BaseFields}}
+    {{.FieldName}} {{.FieldType}} ` + "`" + `db:"{{.ColumnName}}" json:"{{.JsonTag}}"` + "`" + `
+{{- end}}
+{{- range .Fields}}
+    {{.FieldName}} {{.FieldType}} ` + "`" + `db:"{{.ColumnName}}" json:"{{.JsonTag}}"` + "`" + `
+{{- end}}
+}
+
+// TableName returns the database table that stores {{.ModelName}} records
+func ({{.ModelName}}) TableName() string {
+    return "{{.Table}}"
+}
+`
+
+// RepositorySQLXTemplate - Template for repositories built on github.com/jmoiron/sqlx
+const RepositorySQLXTemplate = `package repository
+
+import (
+	"fmt"
+{{- if or .SQL.CreatedAt .SQL.UpdatedAt .SQL.DeletedAt}}
+	"time"
+{{- end}}
+
+	"{{.Imports.Models}}"
+	"github.com/jmoiron/sqlx"
+)
+
+` + repositoryInterface + `// {{.RepositoryName}}Repo is the sqlx implementation of {{.RepositoryName}}Repository
+type {{.RepositoryName}}Repo struct {
+	DB *sqlx.DB
+}
+
+// New{{.RepositoryName}}Repository creates a new instance of {{.RepositoryName}}Repository.
+// MySQL connections need parseTime=true in their DSN to scan DATETIME columns.
+func New{{.RepositoryName}}Repository(db *sqlx.DB) {{.RepositoryName}}Repository {
+	return &{{.RepositoryName}}Repo{DB: db}
+}
+
+// {{.Var}}Select selects every column of {{.Table}}
+const {{.Var}}Select = "SELECT {{.SQL.Select}} FROM {{.Table}}"
+
+// {{.Var}}SortColumns are the columns GetAllSorted accepts
+var {{.Var}}SortColumns = map[string]bool{ {{- range .SQL.SortColumns}}"{{.}}": true, {{end -}} }
+
+// Create adds a new {{.RepositoryName}} to the database
+func (r *{{.RepositoryName}}Repo) Create(item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error) {
+	if err := r.insert(r.DB, item); err != nil {
+		return nil, fmt.Errorf("failed to create {{.RepositoryName}}: %w", err)
+	}
+	return item, nil
+}
+
+// CreateMany adds multiple {{.RepositoryName}} entities to the database in one transaction
+func (r *{{.RepositoryName}}Repo) CreateMany(items []*models.{{.RepositoryName}}) ([]*models.{{.RepositoryName}}, error) {
+	tx, err := r.DB.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multiple {{.RepositoryName}}: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, item := range items {
+		if err := r.insert(tx, item); err != nil {
+			return nil, fmt.Errorf("failed to create multiple {{.RepositoryName}}: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to create multiple {{.RepositoryName}}: %w", err)
+	}
+	return items, nil
+}
+
+// insert writes one {{.RepositoryName}} and sets its ID
+func (r *{{.RepositoryName}}Repo) insert(db sqlx.Ext, item *models.{{.RepositoryName}}) error {
+{{- if or .SQL.CreatedAt .SQL.UpdatedAt}}
+	now := time.Now()
+{{- if .SQL.CreatedAt}}
+	item.{{.SQL.CreatedAt}} = {{.SQL.CreatedAtValue}}
+{{- end}}
+{{- if .SQL.UpdatedAt}}
+	item.{{.SQL.UpdatedAt}} = {{.SQL.UpdatedAtValue}}
+{{- end}}
+{{- end}}
+	result, err := sqlx.NamedExec(db, "INSERT INTO {{.Table}} ({{.SQL.InsertColumns}}) VALUES ({{.SQL.InsertValues}})", item)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	item.ID = uint(id)
+	return nil
+}
+
+// FindByID retrieves a {{.RepositoryName}} by its ID
+func (r *{{.RepositoryName}}Repo) FindByID(id uint) (*models.{{.RepositoryName}}, error) {
+	var item models.{{.RepositoryName}}
+	query := {{.Var}}Select + " WHERE {{.PrimaryKey}} = ?{{if .SQL.NotDeleted}} AND {{.SQL.NotDeleted}}{{end}}"
+	if err := r.DB.Get(&item, r.DB.Rebind(query), id); err != nil {
+		return nil, fmt.Errorf("failed to find {{.RepositoryName}} by ID: %w", err)
+	}
+	return &item, nil
+}
+
+{{- range .Fields}}{{if .Unique}}
+
+// FindBy{{.FieldName}} retrieves a {{$.RepositoryName}} by its unique {{.ColumnName}}
+func (r *{{$.RepositoryName}}Repo) FindBy{{.FieldName}}({{.ParamName}} {{.FieldType}}) (*models.{{$.RepositoryName}}, error) {
+	var item models.{{$.RepositoryName}}
+	query := {{$.Var}}Select + " WHERE {{.ColumnName}} = ?{{if $.SQL.NotDeleted}} AND {{$.SQL.NotDeleted}}{{end}}"
+	if err := r.DB.Get(&item, r.DB.Rebind(query), {{.ParamName}}); err != nil {
+		return nil, fmt.Errorf("failed to find {{$.RepositoryName}} by {{.ColumnName}}: %w", err)
+	}
+	return &item, nil
+}
+{{- end}}{{end}}
+
+// GetAll retrieves all {{.RepositoryName}} entities from the database
+func (r *{{.RepositoryName}}Repo) GetAll() ([]*models.{{.RepositoryName}}, error) {
+	items := []*models.{{.RepositoryName}}{}
+	query := {{.Var}}Select + "{{if .SQL.NotDeleted}} WHERE {{.SQL.NotDeleted}}{{end}} ORDER BY {{.PrimaryKey}}"
+	if err := r.DB.Select(&items, query); err != nil {
+		return nil, fmt.Errorf("failed to get all {{.RepositoryName}}: %w", err)
+	}
+	return items, nil
+}
+
+// GetPaged retrieves a paginated list of {{.RepositoryName}} entities
+func (r *{{.RepositoryName}}Repo) GetPaged(page, pageSize int) ([]*models.{{.RepositoryName}}, error) {
+	items := []*models.{{.RepositoryName}}{}
+	offset := (page - 1) * pageSize
+	query := {{.Var}}Select + "{{if .SQL.NotDeleted}} WHERE {{.SQL.NotDeleted}}{{end}} ORDER BY {{.PrimaryKey}} LIMIT ? OFFSET ?"
+	if err := r.DB.Select(&items, r.DB.Rebind(query), pageSize, offset); err != nil {
+		return nil, fmt.Errorf("failed to get paginated {{.RepositoryName}}: %w", err)
+	}
+	return items, nil
+}
+
+// GetAllSorted retrieves all {{.RepositoryName}} entities sorted by a column
+func (r *{{.RepositoryName}}Repo) GetAllSorted(orderBy string, ascending bool) ([]*models.{{.RepositoryName}}, error) {
+	// Column names cannot be bound as parameters, so only known columns are accepted
+	if !{{.Var}}SortColumns[orderBy] {
+		return nil, fmt.Errorf("failed to get sorted {{.RepositoryName}}: unknown column %q", orderBy)
+	}
+	direction := "DESC"
+	if ascending {
+		direction = "ASC"
+	}
+
+	items := []*models.{{.RepositoryName}}{}
+	query := {{.Var}}Select + "{{if .SQL.NotDeleted}} WHERE {{.SQL.NotDeleted}}{{end}} ORDER BY " + orderBy + " " + direction
+	if err := r.DB.Select(&items, query); err != nil {
+		return nil, fmt.Errorf("failed to get sorted {{.RepositoryName}}: %w", err)
+	}
+	return items, nil
+}
+
+// UpdateOne updates a single {{.RepositoryName}} entity
+func (r *{{.RepositoryName}}Repo) UpdateOne(item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error) {
+	return r.UpdateByID(item.ID, item)
+}
+
+// UpdateByID updates a {{.RepositoryName}} entity by its ID
+func (r *{{.RepositoryName}}Repo) UpdateByID(id uint, item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error) {
+	item.ID = id
+	if err := r.update(r.DB, item); err != nil {
+		return nil, fmt.Errorf("failed to update {{.RepositoryName}} by ID: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateByEntity updates a {{.RepositoryName}} entity based on the entity itself
+func (r *{{.RepositoryName}}Repo) UpdateByEntity(item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error) {
+	return r.UpdateByID(item.ID, item)
+}
+
+// UpdateMany updates multiple {{.RepositoryName}} entities in one transaction
+func (r *{{.RepositoryName}}Repo) UpdateMany(items []*models.{{.RepositoryName}}) ([]*models.{{.RepositoryName}}, error) {
+	tx, err := r.DB.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update multiple {{.RepositoryName}}: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, item := range items {
+		if err := r.update(tx, item); err != nil {
+			return nil, fmt.Errorf("failed to update multiple {{.RepositoryName}}: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to update multiple {{.RepositoryName}}: %w", err)
+	}
+	return items, nil
+}
+
+// update writes every column of one {{.RepositoryName}}
+func (r *{{.RepositoryName}}Repo) update(db sqlx.Ext, item *models.{{.RepositoryName}}) error {
+{{- if .SQL.UpdatedAt}}
+	now := time.Now()
+	item.{{.SQL.UpdatedAt}} = {{.SQL.UpdatedAtValue}}
+{{- end}}
+	_, err := sqlx.NamedExec(db, "UPDATE {{.Table}} SET {{.SQL.UpdateSet}} WHERE {{.SQL.UpdateWhere}}{{if .SQL.NotDeleted}} AND {{.SQL.NotDeleted}}{{end}}", item)
+	return err
+}
+
+// SoftDelete marks a {{.RepositoryName}} entity as deleted (without actually deleting it)
+func (r *{{.RepositoryName}}Repo) SoftDelete(id uint) error {
+{{- if .SQL.DeletedAt}}
+	query := r.DB.Rebind("UPDATE {{.Table}} SET {{.SQL.DeletedAt}} = ? WHERE {{.PrimaryKey}} = ?")
+	if _, err := r.DB.Exec(query, time.Now(), id); err != nil {
+		return fmt.Errorf("failed to soft delete {{.RepositoryName}}: %w", err)
+	}
+	return nil
+{{- else}}
+	return fmt.Errorf("failed to soft delete {{.RepositoryName}}: {{.Table}} has no deleted_at column")
+{{- end}}
+}
+
+// DeleteOne deletes a single {{.RepositoryName}} by its ID
+func (r *{{.RepositoryName}}Repo) DeleteOne(id uint) error {
+	if _, err := r.DB.Exec(r.DB.Rebind("DELETE FROM {{.Table}} WHERE {{.PrimaryKey}} = ?"), id); err != nil {
+		return fmt.Errorf("failed to delete {{.RepositoryName}} by ID: %w", err)
+	}
+	return nil
+}
+
+// DeleteMany deletes multiple {{.RepositoryName}} entities by their IDs
+func (r *{{.RepositoryName}}Repo) DeleteMany(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query, args, err := sqlx.In("DELETE FROM {{.Table}} WHERE {{.PrimaryKey}} IN (?)", ids)
+	if err != nil {
+		return fmt.Errorf("failed to delete multiple {{.RepositoryName}} by IDs: %w", err)
+	}
+	if _, err := r.DB.Exec(r.DB.Rebind(query), args...); err != nil {
+		return fmt.Errorf("failed to delete multiple {{.RepositoryName}} by IDs: %w", err)
+	}
+	return nil
+}
+
+// Count returns the total number of {{.RepositoryName}} records in the database
+func (r *{{.RepositoryName}}Repo) Count() (int64, error) {
+	var count int64
+	if err := r.DB.Get(&count, "SELECT COUNT(*) FROM {{.Table}}{{if .SQL.NotDeleted}} WHERE {{.SQL.NotDeleted}}{{end}}"); err != nil {
+		return 0, fmt.Errorf("failed to count {{.RepositoryName}}: %w", err)
+	}
+	return count, nil
+}
+`
+
+// RepositoryDatabaseSQLTemplate - Template for repositories built on the standard library's database/sql
+const RepositoryDatabaseSQLTemplate = `package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+{{- if or .SQL.CreatedAt .SQL.UpdatedAt .SQL.DeletedAt}}
+	"time"
+{{- end}}
+
+	"{{.Imports.Models}}"
+)
+
+` + repositoryInterface + `// {{.RepositoryName}}Repo is the database/sql implementation of {{.RepositoryName}}Repository
+type {{.RepositoryName}}Repo struct {
+	DB *sql.DB
+}
+
+// New{{.RepositoryName}}Repository creates a new instance of {{.RepositoryName}}Repository.
+// Queries use '?' placeholders (MySQL, SQLite); MySQL connections need parseTime=true in their DSN.
+func New{{.RepositoryName}}Repository(db *sql.DB) {{.RepositoryName}}Repository {
+	return &{{.RepositoryName}}Repo{DB: db}
+}
+
+// {{.Var}}Select selects every column of {{.Table}} in the order scan{{.RepositoryName}} reads them
+const {{.Var}}Select = "SELECT {{.SQL.Select}} FROM {{.Table}}"
+
+// {{.Var}}SortColumns are the columns GetAllSorted accepts
+var {{.Var}}SortColumns = map[string]bool{ {{- range .SQL.SortColumns}}"{{.}}": true, {{end -}} }
+
+// scan{{.RepositoryName}} reads one row selected with {{.Var}}Select
+func scan{{.RepositoryName}}(row interface{ Scan(dest ...interface{}) error }) (*models.{{.RepositoryName}}, error) {
+	var item models.{{.RepositoryName}}
+	if err := row.Scan({{range $i, $field := .SQL.Columns}}{{if $i}}, {{end}}&item.{{$field.FieldName}}{{end}}); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// query{{.RepositoryName}} runs a select built on {{.Var}}Select and scans every row
+func (r *{{.RepositoryName}}Repo) query{{.RepositoryName}}(query string, args ...interface{}) ([]*models.{{.RepositoryName}}, error) {
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []*models.{{.RepositoryName}}{}
+	for rows.Next() {
+		item, err := scan{{.RepositoryName}}(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// Create adds a new {{.RepositoryName}} to the database
+func (r *{{.RepositoryName}}Repo) Create(item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error) {
+	if err := r.insert(r.DB.Exec, item); err != nil {
+		return nil, fmt.Errorf("failed to create {{.RepositoryName}}: %w", err)
+	}
+	return item, nil
+}
+
+// CreateMany adds multiple {{.RepositoryName}} entities to the database in one transaction
+func (r *{{.RepositoryName}}Repo) CreateMany(items []*models.{{.RepositoryName}}) ([]*models.{{.RepositoryName}}, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multiple {{.RepositoryName}}: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, item := range items {
+		if err := r.insert(tx.Exec, item); err != nil {
+			return nil, fmt.Errorf("failed to create multiple {{.RepositoryName}}: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to create multiple {{.RepositoryName}}: %w", err)
+	}
+	return items, nil
+}
+
+// insert writes one {{.RepositoryName}} with exec, the Exec method of the database or a transaction, and sets its ID
+func (r *{{.RepositoryName}}Repo) insert(exec func(string, ...interface{}) (sql.Result, error), item *models.{{.RepositoryName}}) error {
+{{- if or .SQL.CreatedAt .SQL.UpdatedAt}}
+	now := time.Now()
+{{- if .SQL.CreatedAt}}
+	item.{{.SQL.CreatedAt}} = {{.SQL.CreatedAtValue}}
+{{- end}}
+{{- if .SQL.UpdatedAt}}
+	item.{{.SQL.UpdatedAt}} = {{.SQL.UpdatedAtValue}}
+{{- end}}
+{{- end}}
+	result, err := exec("INSERT INTO {{.Table}} ({{.SQL.InsertColumns}}) VALUES ({{.SQL.InsertValues}})",
+		{{- range .SQL.Insert}} item.{{.FieldName}},{{end}})
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	item.ID = uint(id)
+	return nil
+}
+
+// FindByID retrieves a {{.RepositoryName}} by its ID
+func (r *{{.RepositoryName}}Repo) FindByID(id uint) (*models.{{.RepositoryName}}, error) {
+	row := r.DB.QueryRow({{.Var}}Select+" WHERE {{.PrimaryKey}} = ?{{if .SQL.NotDeleted}} AND {{.SQL.NotDeleted}}{{end}}", id)
+	item, err := scan{{.RepositoryName}}(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find {{.RepositoryName}} by ID: %w", err)
+	}
+	return item, nil
+}
+
+{{- range .Fields}}{{if .Unique}}
+
+// FindBy{{.FieldName}} retrieves a {{$.RepositoryName}} by its unique {{.ColumnName}}
+func (r *{{$.RepositoryName}}Repo) FindBy{{.FieldName}}({{.ParamName}} {{.FieldType}}) (*models.{{$.RepositoryName}}, error) {
+	row := r.DB.QueryRow({{$.Var}}Select+" WHERE {{.ColumnName}} = ?{{if $.SQL.NotDeleted}} AND {{$.SQL.NotDeleted}}{{end}}", {{.ParamName}})
+	item, err := scan{{$.RepositoryName}}(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find {{$.RepositoryName}} by {{.ColumnName}}: %w", err)
+	}
+	return item, nil
+}
+{{- end}}{{end}}
+
+// GetAll retrieves all {{.RepositoryName}} entities from the database
+func (r *{{.RepositoryName}}Repo) GetAll() ([]*models.{{.RepositoryName}}, error) {
+	items, err := r.query{{.RepositoryName}}({{.Var}}Select + "{{if .SQL.NotDeleted}} WHERE {{.SQL.NotDeleted}}{{end}} ORDER BY {{.PrimaryKey}}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all {{.RepositoryName}}: %w", err)
+	}
+	return items, nil
+}
+
+// GetPaged retrieves a paginated list of {{.RepositoryName}} entities
+func (r *{{.RepositoryName}}Repo) GetPaged(page, pageSize int) ([]*models.{{.RepositoryName}}, error) {
+	offset := (page - 1) * pageSize
+	items, err := r.query{{.RepositoryName}}({{.Var}}Select+"{{if .SQL.NotDeleted}} WHERE {{.SQL.NotDeleted}}{{end}} ORDER BY {{.PrimaryKey}} LIMIT ? OFFSET ?", pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get paginated {{.RepositoryName}}: %w", err)
+	}
+	return items, nil
+}
+
+// GetAllSorted retrieves all {{.RepositoryName}} entities sorted by a column
+func (r *{{.RepositoryName}}Repo) GetAllSorted(orderBy string, ascending bool) ([]*models.{{.RepositoryName}}, error) {
+	// Column names cannot be bound as parameters, so only known columns are accepted
+	if !{{.Var}}SortColumns[orderBy] {
+		return nil, fmt.Errorf("failed to get sorted {{.RepositoryName}}: unknown column %q", orderBy)
+	}
+	direction := "DESC"
+	if ascending {
+		direction = "ASC"
+	}
+
+	items, err := r.query{{.RepositoryName}}({{.Var}}Select + "{{if .SQL.NotDeleted}} WHERE {{.SQL.NotDeleted}}{{end}} ORDER BY " + orderBy + " " + direction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sorted {{.RepositoryName}}: %w", err)
+	}
+	return items, nil
+}
+
+// UpdateOne updates a single {{.RepositoryName}} entity
+func (r *{{.RepositoryName}}Repo) UpdateOne(item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error) {
+	return r.UpdateByID(item.ID, item)
+}
+
+// UpdateByID updates a {{.RepositoryName}} entity by its ID
+func (r *{{.RepositoryName}}Repo) UpdateByID(id uint, item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error) {
+	item.ID = id
+	if err := r.update(r.DB.Exec, item); err != nil {
+		return nil, fmt.Errorf("failed to update {{.RepositoryName}} by ID: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateByEntity updates a {{.RepositoryName}} entity based on the entity itself
+func (r *{{.RepositoryName}}Repo) UpdateByEntity(item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error) {
+	return r.UpdateByID(item.ID, item)
+}
+
+// UpdateMany updates multiple {{.RepositoryName}} entities in one transaction
+func (r *{{.RepositoryName}}Repo) UpdateMany(items []*models.{{.RepositoryName}}) ([]*models.{{.RepositoryName}}, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update multiple {{.RepositoryName}}: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, item := range items {
+		if err := r.update(tx.Exec, item); err != nil {
+			return nil, fmt.Errorf("failed to update multiple {{.RepositoryName}}: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to update multiple {{.RepositoryName}}: %w", err)
+	}
+	return items, nil
+}
+
+// update writes every column of one {{.RepositoryName}} with exec
+func (r *{{.RepositoryName}}Repo) update(exec func(string, ...interface{}) (sql.Result, error), item *models.{{.RepositoryName}}) error {
+{{- if .SQL.UpdatedAt}}
+	now := time.Now()
+	item.{{.SQL.UpdatedAt}} = {{.SQL.UpdatedAtValue}}
+{{- end}}
+	_, err := exec("UPDATE {{.Table}} SET {{.SQL.UpdateSet}} WHERE {{.SQL.UpdateWhere}}{{if .SQL.NotDeleted}} AND {{.SQL.NotDeleted}}{{end}}",
+		{{- range .SQL.Update}} item.{{.FieldName}},{{end}} item.ID)
+	return err
+}
+
+// SoftDelete marks a {{.RepositoryName}} entity as deleted (without actually deleting it)
+func (r *{{.RepositoryName}}Repo) SoftDelete(id uint) error {
+{{- if .SQL.DeletedAt}}
+	if _, err := r.DB.Exec("UPDATE {{.Table}} SET {{.SQL.DeletedAt}} = ? WHERE {{.PrimaryKey}} = ?", time.Now(), id); err != nil {
+		return fmt.Errorf("failed to soft delete {{.RepositoryName}}: %w", err)
+	}
+	return nil
+{{- else}}
+	return fmt.Errorf("failed to soft delete {{.RepositoryName}}: {{.Table}} has no deleted_at column")
+{{- end}}
+}
+
+// DeleteOne deletes a single {{.RepositoryName}} by its ID
+func (r *{{.RepositoryName}}Repo) DeleteOne(id uint) error {
+	if _, err := r.DB.Exec("DELETE FROM {{.Table}} WHERE {{.PrimaryKey}} = ?", id); err != nil {
+		return fmt.Errorf("failed to delete {{.RepositoryName}} by ID: %w", err)
+	}
+	return nil
+}
+
+// DeleteMany deletes multiple {{.RepositoryName}} entities by their IDs
+func (r *{{.RepositoryName}}Repo) DeleteMany(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	if _, err := r.DB.Exec("DELETE FROM {{.Table}} WHERE {{.PrimaryKey}} IN ("+placeholders+")", args...); err != nil {
+		return fmt.Errorf("failed to delete multiple {{.RepositoryName}} by IDs: %w", err)
+	}
+	return nil
+}
+
+// Count returns the total number of {{.RepositoryName}} records in the database
+func (r *{{.RepositoryName}}Repo) Count() (int64, error) {
+	var count int64
+	if err := r.DB.QueryRow("SELECT COUNT(*) FROM {{.Table}}{{if .SQL.NotDeleted}} WHERE {{.SQL.NotDeleted}}{{end}}").Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count {{.RepositoryName}}: %w", err)
+	}
+	return count, nil
+}
+`
+
+// RepositoryPgxTemplate - Template for PostgreSQL repositories built on github.com/jackc/pgx/v5
+const RepositoryPgxTemplate = `package repository
+
+import (
+	"context"
+	"fmt"
+{{- if or .SQL.CreatedAt .SQL.UpdatedAt .SQL.DeletedAt}}
+	"time"
+{{- end}}
+
+	"{{.Imports.Models}}"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+` + repositoryInterface + `// {{.RepositoryName}}Repo is the pgx implementation of {{.RepositoryName}}Repository
+type {{.RepositoryName}}Repo struct {
+	DB *pgxpool.Pool
+}
+
+// New{{.RepositoryName}}Repository creates a new instance of {{.RepositoryName}}Repository
+func New{{.RepositoryName}}Repository(db *pgxpool.Pool) {{.RepositoryName}}Repository {
+	return &{{.RepositoryName}}Repo{DB: db}
+}
+
+// {{.Var}}Select selects every column of {{.Table}}
+const {{.Var}}Select = "SELECT {{.SQL.Select}} FROM {{.Table}}"
+
+// {{.Var}}SortColumns are the columns GetAllSorted accepts
+var {{.Var}}SortColumns = map[string]bool{ {{- range .SQL.SortColumns}}"{{.}}": true, {{end -}} }
+
+// {{.Var}}Querier is implemented by both the pool and transactions
+type {{.Var}}Querier interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// query{{.RepositoryName}} runs a select built on {{.Var}}Select and collects every row
+func (r *{{.RepositoryName}}Repo) query{{.RepositoryName}}(query string, args ...interface{}) ([]*models.{{.RepositoryName}}, error) {
+	rows, err := r.DB.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	items, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[models.{{.RepositoryName}}])
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []*models.{{.RepositoryName}}{}
+	}
+	return items, nil
+}
+
+// find{{.RepositoryName}} runs a select built on {{.Var}}Select that matches at most one row
+func (r *{{.RepositoryName}}Repo) find{{.RepositoryName}}(query string, args ...interface{}) (*models.{{.RepositoryName}}, error) {
+	rows, err := r.DB.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[models.{{.RepositoryName}}])
+}
+
+// Create adds a new {{.RepositoryName}} to the database
+func (r *{{.RepositoryName}}Repo) Create(item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error) {
+	if err := r.insert(r.DB, item); err != nil {
+		return nil, fmt.Errorf("failed to create {{.RepositoryName}}: %w", err)
+	}
+	return item, nil
+}
+
+// CreateMany adds multiple {{.RepositoryName}} entities to the database in one transaction
+func (r *{{.RepositoryName}}Repo) CreateMany(items []*models.{{.RepositoryName}}) ([]*models.{{.RepositoryName}}, error) {
+	ctx := context.Background()
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multiple {{.RepositoryName}}: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, item := range items {
+		if err := r.insert(tx, item); err != nil {
+			return nil, fmt.Errorf("failed to create multiple {{.RepositoryName}}: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create multiple {{.RepositoryName}}: %w", err)
+	}
+	return items, nil
+}
+
+// insert writes one {{.RepositoryName}} and reads back its ID
+func (r *{{.RepositoryName}}Repo) insert(db {{.Var}}Querier, item *models.{{.RepositoryName}}) error {
+{{- if or .SQL.CreatedAt .SQL.UpdatedAt}}
+	now := time.Now()
+{{- if .SQL.CreatedAt}}
+	item.{{.SQL.CreatedAt}} = {{.SQL.CreatedAtValue}}
+{{- end}}
+{{- if .SQL.UpdatedAt}}
+	item.{{.SQL.UpdatedAt}} = {{.SQL.UpdatedAtValue}}
+{{- end}}
+{{- end}}
+	row := db.QueryRow(context.Background(), "INSERT INTO {{.Table}} ({{.SQL.InsertColumns}}) VALUES ({{.SQL.InsertValues}}) RETURNING {{.PrimaryKey}}",
+		{{- range .SQL.Insert}} item.{{.FieldName}},{{end}})
+	return row.Scan(&item.ID)
+}
+
+// FindByID retrieves a {{.RepositoryName}} by its ID
+func (r *{{.RepositoryName}}Repo) FindByID(id uint) (*models.{{.RepositoryName}}, error) {
+	item, err := r.find{{.RepositoryName}}({{.Var}}Select+" WHERE {{.PrimaryKey}} = $1{{if .SQL.NotDeleted}} AND {{.SQL.NotDeleted}}{{end}}", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find {{.RepositoryName}} by ID: %w", err)
+	}
+	return item, nil
+}
+
+{{- range .Fields}}{{if .Unique}}
+
+// FindBy{{.FieldName}} retrieves a {{$.RepositoryName}} by its unique {{.ColumnName}}
+func (r *{{$.RepositoryName}}Repo) FindBy{{.FieldName}}({{.ParamName}} {{.FieldType}}) (*models.{{$.RepositoryName}}, error) {
+	item, err := r.find{{$.RepositoryName}}({{$.Var}}Select+" WHERE {{.ColumnName}} = $1{{if $.SQL.NotDeleted}} AND {{$.SQL.NotDeleted}}{{end}}", {{.ParamName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find {{$.RepositoryName}} by {{.ColumnName}}: %w", err)
+	}
+	return item, nil
+}
+{{- end}}{{end}}
+
+// GetAll retrieves all {{.RepositoryName}} entities from the database
+func (r *{{.RepositoryName}}Repo) GetAll() ([]*models.{{.RepositoryName}}, error) {
+	items, err := r.query{{.RepositoryName}}({{.Var}}Select + "{{if .SQL.NotDeleted}} WHERE {{.SQL.NotDeleted}}{{end}} ORDER BY {{.PrimaryKey}}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all {{.RepositoryName}}: %w", err)
+	}
+	return items, nil
+}
+
+// GetPaged retrieves a paginated list of {{.RepositoryName}} entities
+func (r *{{.RepositoryName}}Repo) GetPaged(page, pageSize int) ([]*models.{{.RepositoryName}}, error) {
+	offset := (page - 1) * pageSize
+	items, err := r.query{{.RepositoryName}}({{.Var}}Select+"{{if .SQL.NotDeleted}} WHERE {{.SQL.NotDeleted}}{{end}} ORDER BY {{.PrimaryKey}} LIMIT $1 OFFSET $2", pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get paginated {{.RepositoryName}}: %w", err)
+	}
+	return items, nil
+}
+
+// GetAllSorted retrieves all {{.RepositoryName}} entities sorted by a column
+func (r *{{.RepositoryName}}Repo) GetAllSorted(orderBy string, ascending bool) ([]*models.{{.RepositoryName}}, error) {
+	// Column names cannot be bound as parameters, so only known columns are accepted
+	if !{{.Var}}SortColumns[orderBy] {
+		return nil, fmt.Errorf("failed to get sorted {{.RepositoryName}}: unknown column %q", orderBy)
+	}
+	direction := "DESC"
+	if ascending {
+		direction = "ASC"
+	}
+
+	items, err := r.query{{.RepositoryName}}({{.Var}}Select + "{{if .SQL.NotDeleted}} WHERE {{.SQL.NotDeleted}}{{end}} ORDER BY " + orderBy + " " + direction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sorted {{.RepositoryName}}: %w", err)
+	}
+	return items, nil
+}
+
+// UpdateOne updates a single {{.RepositoryName}} entity
+func (r *{{.RepositoryName}}Repo) UpdateOne(item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error) {
+	return r.UpdateByID(item.ID, item)
+}
+
+// UpdateByID updates a {{.RepositoryName}} entity by its ID
+func (r *{{.RepositoryName}}Repo) UpdateByID(id uint, item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error) {
+	item.ID = id
+	if err := r.update(r.DB, item); err != nil {
+		return nil, fmt.Errorf("failed to update {{.RepositoryName}} by ID: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateByEntity updates a {{.RepositoryName}} entity based on the entity itself
+func (r *{{.RepositoryName}}Repo) UpdateByEntity(item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error) {
+	return r.UpdateByID(item.ID, item)
+}
+
+// UpdateMany updates multiple {{.RepositoryName}} entities in one transaction
+func (r *{{.RepositoryName}}Repo) UpdateMany(items []*models.{{.RepositoryName}}) ([]*models.{{.RepositoryName}}, error) {
+	ctx := context.Background()
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update multiple {{.RepositoryName}}: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, item := range items {
+		if err := r.update(tx, item); err != nil {
+			return nil, fmt.Errorf("failed to update multiple {{.RepositoryName}}: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to update multiple {{.RepositoryName}}: %w", err)
+	}
+	return items, nil
+}
+
+// update writes every column of one {{.RepositoryName}}
+func (r *{{.RepositoryName}}Repo) update(db {{.Var}}Querier, item *models.{{.RepositoryName}}) error {
+{{- if .SQL.UpdatedAt}}
+	now := time.Now()
+	item.{{.SQL.UpdatedAt}} = {{.SQL.UpdatedAtValue}}
+{{- end}}
+	var id uint
+	row := db.QueryRow(context.Background(), "UPDATE {{.Table}} SET {{.SQL.UpdateSet}} WHERE {{.SQL.UpdateWhere}}{{if .SQL.NotDeleted}} AND {{.SQL.NotDeleted}}{{end}} RETURNING {{.PrimaryKey}}",
+		{{- range .SQL.Update}} item.{{.FieldName}},{{end}} item.ID)
+	return row.Scan(&id)
+}
+
+// SoftDelete marks a {{.RepositoryName}} entity as deleted (without actually deleting it)
+func (r *{{.RepositoryName}}Repo) SoftDelete(id uint) error {
+{{- if .SQL.DeletedAt}}
+	if _, err := r.DB.Exec(context.Background(), "UPDATE {{.Table}} SET {{.SQL.DeletedAt}} = $1 WHERE {{.PrimaryKey}} = $2", time.Now(), id); err != nil {
+		return fmt.Errorf("failed to soft delete {{.RepositoryName}}: %w", err)
+	}
+	return nil
+{{- else}}
+	return fmt.Errorf("failed to soft delete {{.RepositoryName}}: {{.Table}} has no deleted_at column")
+{{- end}}
+}
+
+// DeleteOne deletes a single {{.RepositoryName}} by its ID
+func (r *{{.RepositoryName}}Repo) DeleteOne(id uint) error {
+	if _, err := r.DB.Exec(context.Background(), "DELETE FROM {{.Table}} WHERE {{.PrimaryKey}} = $1", id); err != nil {
+		return fmt.Errorf("failed to delete {{.RepositoryName}} by ID: %w", err)
+	}
+	return nil
+}
+
+// DeleteMany deletes multiple {{.RepositoryName}} entities by their IDs
+func (r *{{.RepositoryName}}Repo) DeleteMany(ids []uint) error {
+	values := make([]int64, len(ids))
+	for i, id := range ids {
+		values[i] = int64(id)
+	}
+	if _, err := r.DB.Exec(context.Background(), "DELETE FROM {{.Table}} WHERE {{.PrimaryKey}} = ANY($1)", values); err != nil {
+		return fmt.Errorf("failed to delete multiple {{.RepositoryName}} by IDs: %w", err)
+	}
+	return nil
+}
+
+// Count returns the total number of {{.RepositoryName}} records in the database
+func (r *{{.RepositoryName}}Repo) Count() (int64, error) {
+	var count int64
+	if err := r.DB.QueryRow(context.Background(), "SELECT COUNT(*) FROM {{.Table}}{{if .SQL.NotDeleted}} WHERE {{.SQL.NotDeleted}}{{end}}").Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count {{.RepositoryName}}: %w", err)
+	}
+	return count, nil
+}
+`
+
+// RepositoryTestSQLTemplate - Template for the sqlx and database/sql repository tests, run against an in-memory SQLite database
+const RepositoryTestSQLTemplate = `package repository
+
+import (
+{{- if ne .ORM "sqlx"}}
+	"database/sql"
+{{- end}}
+	"testing"
+{{- if .NeedsTime}}
+	"time"
+{{- end}}
+
+	"{{.Imports.Models}}"
+	_ "github.com/glebarez/go-sqlite" // Pure Go SQLite driver registered as "sqlite", so the tests run without cgo
+{{- if eq .ORM "sqlx"}}
+	"github.com/jmoiron/sqlx"
+{{- end}}
+)
+
+// new{{.Name}}TestDB opens an in-memory SQLite database with the {{.Table}} table created
+func new{{.Name}}TestDB(t *testing.T) {{.DBType}} {
+	t.Helper()
+	db, err := {{if eq .ORM "sqlx"}}sqlx{{else}}sql{{end}}.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+
+	// Every connection to ":memory:" gets its own database, so keep a single connection
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	if _, err := db.Exec({{printf "%q" .SQL.SQLiteSchema}}); err != nil {
+		t.Fatalf("failed to create {{.Table}}: %v", err)
+	}
+	return db
+}
+
+` + repositoryTestCases
@@ -5,29 +5,11 @@ const RepositoryTemplate = `package repository
 
 import (
 	"fmt"
-	"goi/models"  // Import your models (e.g., User)
+	"{{.Imports.Models}}" // Import your models (e.g., User)
 	"gorm.io/gorm"
 )
 
-// {{.RepositoryName}}Repository defines the interface for CRUD operations
-type {{.RepositoryName}}Repository interface {
-	Create(item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error)
-	CreateMany(items []*models.{{.RepositoryName}}) ([]*models.{{.RepositoryName}}, error)
-	FindByID(id uint) (*models.{{.RepositoryName}}, error)
-	GetAll() ([]*models.{{.RepositoryName}}, error)
-	GetPaged(page, pageSize int) ([]*models.{{.RepositoryName}}, error)
-	GetAllSorted(orderBy string, ascending bool) ([]*models.{{.RepositoryName}}, error)
-	UpdateOne(item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error)
-	UpdateByID(id uint, item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error)
-	UpdateByEntity(item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error)
-	UpdateMany(items []*models.{{.RepositoryName}}) ([]*models.{{.RepositoryName}}, error)
-	SoftDelete(id uint) error
-	DeleteOne(id uint) error
-	DeleteMany(ids []uint) error
-	Count() (int64, error)
-}
-
-// {{.RepositoryName}}Repo is the concrete implementation of {{.RepositoryName}}Repository
+` + repositoryInterface + `// {{.RepositoryName}}Repo is the concrete implementation of {{.RepositoryName}}Repository
 type {{.RepositoryName}}Repo struct {
 	DB *gorm.DB
 }
@@ -62,6 +44,18 @@ func (r *{{.RepositoryName}}Repo) FindByID(id uint) (*models.{{.RepositoryName}}
 	return &item, nil
 }
 
+{{- range .Fields}}{{if .Unique}}
+
+// FindBy{{.FieldName}} retrieves a {{$.RepositoryName}} by its unique {{.ColumnName}}
+func (r *{{$.RepositoryName}}Repo) FindBy{{.FieldName}}({{.ParamName}} {{.FieldType}}) (*models.{{$.RepositoryName}}, error) {
+	var item models.{{$.RepositoryName}}
+	if err := r.DB.Where("{{.ColumnName}} = ?", {{.ParamName}}).First(&item).Error; err != nil {
+		return nil, fmt.Errorf("failed to find {{$.RepositoryName}} by {{.ColumnName}}: %w", err)
+	}
+	return &item, nil
+}
+{{- end}}{{end}}
+
 // GetAll retrieves all {{.RepositoryName}} entities from the database
 func (r *{{.RepositoryName}}Repo) GetAll() ([]*models.{{.RepositoryName}}, error) {
 	var items []*models.{{.RepositoryName}}
@@ -106,7 +100,7 @@ func (r *{{.RepositoryName}}Repo) UpdateOne(item *models.{{.RepositoryName}}) (*
 
 // UpdateByID updates a {{.RepositoryName}} entity by its ID
 func (r *{{.RepositoryName}}Repo) UpdateByID(id uint, item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error) {
-	if err := r.DB.Model(&models.{{.RepositoryName}}{}).Where("id = ?", id).Updates(item).Error; err != nil {
+	if err := r.DB.Model(&models.{{.RepositoryName}}{}).Where("{{.PrimaryKey}} = ?", id).Updates(item).Error; err != nil {
 		return nil, fmt.Errorf("failed to update {{.RepositoryName}} by ID: %w", err)
 	}
 	return item, nil
@@ -130,7 +124,7 @@ func (r *{{.RepositoryName}}Repo) UpdateMany(items []*models.{{.RepositoryName}}
 
 // SoftDelete marks a {{.RepositoryName}} entity as deleted (without actually deleting it)
 func (r *{{.RepositoryName}}Repo) SoftDelete(id uint) error {
-	if err := r.DB.Model(&models.{{.RepositoryName}}{}).Where("id = ?", id).Update("deleted_at", gorm.Expr("NOW()")).Error; err != nil {
+	if err := r.DB.Model(&models.{{.RepositoryName}}{}).Where("{{.PrimaryKey}} = ?", id).Update("deleted_at", gorm.Expr("NOW()")).Error; err != nil {
 		return fmt.Errorf("failed to soft delete {{.RepositoryName}}: %w", err)
 	}
 	return nil
@@ -146,7 +140,7 @@ func (r *{{.RepositoryName}}Repo) DeleteOne(id uint) error {
 
 // DeleteMany deletes multiple {{.RepositoryName}} entities by their IDs
 func (r *{{.RepositoryName}}Repo) DeleteMany(ids []uint) error {
-	if err := r.DB.Where("id IN ?", ids).Delete(&models.{{.RepositoryName}}{}).Error; err != nil {
+	if err := r.DB.Where("{{.PrimaryKey}} IN ?", ids).Delete(&models.{{.RepositoryName}}{}).Error; err != nil {
 		return fmt.Errorf("failed to delete multiple {{.RepositoryName}} by IDs: %w", err)
 	}
 	return nil
@@ -161,3 +155,28 @@ func (r *{{.RepositoryName}}Repo) Count() (int64, error) {
 	return count, nil
 }
 `
+
+// repositoryInterface is the <Name>Repository interface every ORM backend implements,
+// so handlers and services do not depend on the backend
+const repositoryInterface = `// {{.RepositoryName}}Repository defines the interface for CRUD operations
+type {{.RepositoryName}}Repository interface {
+	Create(item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error)
+	CreateMany(items []*models.{{.RepositoryName}}) ([]*models.{{.RepositoryName}}, error)
+	FindByID(id uint) (*models.{{.RepositoryName}}, error)
+{{- range .Fields}}{{if .Unique}}
+	FindBy{{.FieldName}}({{.ParamName}} {{.FieldType}}) (*models.{{$.RepositoryName}}, error)
+{{- end}}{{end}}
+	GetAll() ([]*models.{{.RepositoryName}}, error)
+	GetPaged(page, pageSize int) ([]*models.{{.RepositoryName}}, error)
+	GetAllSorted(orderBy string, ascending bool) ([]*models.{{.RepositoryName}}, error)
+	UpdateOne(item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error)
+	UpdateByID(id uint, item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error)
+	UpdateByEntity(item *models.{{.RepositoryName}}) (*models.{{.RepositoryName}}, error)
+	UpdateMany(items []*models.{{.RepositoryName}}) ([]*models.{{.RepositoryName}}, error)
+	SoftDelete(id uint) error
+	DeleteOne(id uint) error
+	DeleteMany(ids []uint) error
+	Count() (int64, error)
+}
+
+`
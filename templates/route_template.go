@@ -1,19 +1,15 @@
 package templates
 
-// route_template.go - Template for generating routes
+// route_template.go - Template for generating the router setup file that `goi make resource` registers routes in
 const RouteTemplate = `package routes
 
-import "github.com/gorilla/mux"
+import (
+	"github.com/gin-gonic/gin"
+	{{if .Container}}"{{.Container}}"{{else}}"{{.DBImport}}"{{end}}
+)
 
 // SetupRoutes sets up the routes for the application
-func SetupRoutes() *mux.Router {
-	r := mux.NewRouter()
-
-	// Define routes
-	r.HandleFunc("/{{.RouteName}}", func(w http.ResponseWriter, r *http.Request) {
-		// Handler logic here
-	}).Methods("GET")
-
-	return r
+func SetupRoutes(r *gin.Engine, {{if .Container}}c *{{.ContainerPackage}}.Container{{else}}db {{.DBType}}{{end}}) {
+	// Resource routes are registered below by 'goi make resource'
 }
 `
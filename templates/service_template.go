@@ -1,12 +1,12 @@
 package templates
 
 // service_template.go - Template for generating service files
-const ServiceTemplate = `package service
+const ServiceTemplate = `package services
 
 import (
-	"{{.ModuleName}}/repository" // Import the repository layer dynamically
-	"{{.ModuleName}}/models"     // Import your models (e.g., User)
-	"fmt"
+	"{{.Imports.DTO}}"        // Import the request DTOs
+	"{{.Imports.Repository}}" // Import the repository layer dynamically
+	"{{.Imports.Models}}"     // Import your models (e.g., User)
 )
 
 // {{.ServiceName}}Service provides business logic for {{.ServiceName}} operations
@@ -20,9 +20,13 @@ func New{{.ServiceName}}Service(repo repository.{{.ServiceName}}Repository) *{{.
 }
 
 // Create creates a new {{.ServiceName}} entity
-func (s *{{.ServiceName}}Service) Create(name, email string) (*models.{{.ServiceName}}, error) {
+func (s *{{.ServiceName}}Service) Create(req *dto.{{.ServiceName}}) (*models.{{.ServiceName}}, error) {
 	// Add business logic here if needed (e.g., validation)
-	entity := &models.{{.ServiceName}}{Name: name, Email: email}
+	entity := &models.{{.ServiceName}}{
+{{- range .Fields}}
+		{{.FieldName}}: req.{{.FieldName}},
+{{- end}}
+	}
 	return s.repo.Create(entity)
 }
 
@@ -37,9 +41,14 @@ func (s *{{.ServiceName}}Service) GetAll() ([]*models.{{.ServiceName}}, error) {
 }
 
 // Update updates an existing {{.ServiceName}} entity
-func (s *{{.ServiceName}}Service) Update(id uint, name, email string) (*models.{{.ServiceName}}, error) {
+func (s *{{.ServiceName}}Service) Update(id uint, req *dto.{{.ServiceName}}) (*models.{{.ServiceName}}, error) {
 	// Add business logic here if needed (e.g., validation)
-	entity := &models.{{.ServiceName}}{ID: id, Name: name, Email: email}
+	entity := &models.{{.ServiceName}}{
+{{- range .Fields}}
+		{{.FieldName}}: req.{{.FieldName}},
+{{- end}}
+	}
+	entity.ID = id
 	return s.repo.UpdateByID(id, entity)
 }
 
@@ -0,0 +1,393 @@
+package templates
+
+// tests_template.go - Templates for the tests generated next to each handler and repository
+
+// HandlerTestTemplate - Template for the CRUD handler tests, backed by a fake repository
+const HandlerTestTemplate = `package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+{{- if .NeedsTime}}
+	"time"
+{{- end}}
+
+	"{{.Imports.Models}}"
+	"{{.Imports.Services}}"
+	"github.com/gin-gonic/gin"
+)
+
+` + handlerTestFakes + `// new{{.Name}}TestRouter routes the CRUD endpoints to a handler backed by repo
+func new{{.Name}}TestRouter(repo *fake{{.Name}}Repository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h := New{{.Name}}Handler(services.New{{.Name}}Service(repo))
+	r.GET("/{{.KebabPlural}}", h.Index)
+	r.GET("/{{.KebabPlural}}/:id", h.Show)
+	r.POST("/{{.KebabPlural}}", h.Create)
+	r.PUT("/{{.KebabPlural}}/:id", h.Update)
+	r.DELETE("/{{.KebabPlural}}/:id", h.Delete)
+	return r
+}
+
+` + handlerTestCases
+
+// handlerTestFakes - The fake repository and sample data shared by the handler tests of every HTTP framework
+const handlerTestFakes = `// errFake{{.Name}}NotFound is returned by the fake repository for unknown IDs, whatever the ORM backend
+var errFake{{.Name}}NotFound = errors.New("record not found")
+
+// fake{{.Name}}Repository is an in-memory repository.{{.Name}}Repository; setting err makes every call fail
+type fake{{.Name}}Repository struct {
+	items  map[uint]*models.{{.Name}}
+	nextID uint
+	err    error
+}
+
+func newFake{{.Name}}Repository(seed ...*models.{{.Name}}) *fake{{.Name}}Repository {
+	repo := &fake{{.Name}}Repository{items: map[uint]*models.{{.Name}}{}}
+	for _, item := range seed {
+		repo.Create(item)
+	}
+	return repo
+}
+
+func (r *fake{{.Name}}Repository) Create(item *models.{{.Name}}) (*models.{{.Name}}, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.nextID++
+	item.ID = r.nextID
+	r.items[item.ID] = item
+	return item, nil
+}
+
+func (r *fake{{.Name}}Repository) CreateMany(items []*models.{{.Name}}) ([]*models.{{.Name}}, error) {
+	for _, item := range items {
+		if _, err := r.Create(item); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+func (r *fake{{.Name}}Repository) FindByID(id uint) (*models.{{.Name}}, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	item, ok := r.items[id]
+	if !ok {
+		return nil, errFake{{.Name}}NotFound
+	}
+	return item, nil
+}
+{{- range .Fields}}{{if .Unique}}
+
+func (r *fake{{$.Name}}Repository) FindBy{{.FieldName}}({{.ParamName}} {{.FieldType}}) (*models.{{$.Name}}, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	for _, item := range r.items {
+		if {{if .Nullable}}item.{{.FieldName}} != nil && {{.ParamName}} != nil && *item.{{.FieldName}} == *{{.ParamName}}{{else}}item.{{.FieldName}} == {{.ParamName}}{{end}} {
+			return item, nil
+		}
+	}
+	return nil, errFake{{$.Name}}NotFound
+}
+{{- end}}{{end}}
+
+func (r *fake{{.Name}}Repository) GetAll() ([]*models.{{.Name}}, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	items := []*models.{{.Name}}{}
+	for id := uint(1); id <= r.nextID; id++ {
+		if item, ok := r.items[id]; ok {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (r *fake{{.Name}}Repository) GetPaged(page, pageSize int) ([]*models.{{.Name}}, error) {
+	items, err := r.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	start := (page - 1) * pageSize
+	if start >= len(items) {
+		return []*models.{{.Name}}{}, nil
+	}
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end], nil
+}
+
+func (r *fake{{.Name}}Repository) GetAllSorted(orderBy string, ascending bool) ([]*models.{{.Name}}, error) {
+	return r.GetAll()
+}
+
+func (r *fake{{.Name}}Repository) UpdateOne(item *models.{{.Name}}) (*models.{{.Name}}, error) {
+	return r.UpdateByID(item.ID, item)
+}
+
+func (r *fake{{.Name}}Repository) UpdateByID(id uint, item *models.{{.Name}}) (*models.{{.Name}}, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	item.ID = id
+	r.items[id] = item
+	return item, nil
+}
+
+func (r *fake{{.Name}}Repository) UpdateByEntity(item *models.{{.Name}}) (*models.{{.Name}}, error) {
+	return r.UpdateByID(item.ID, item)
+}
+
+func (r *fake{{.Name}}Repository) UpdateMany(items []*models.{{.Name}}) ([]*models.{{.Name}}, error) {
+	for _, item := range items {
+		if _, err := r.UpdateByID(item.ID, item); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+func (r *fake{{.Name}}Repository) SoftDelete(id uint) error {
+	return r.DeleteOne(id)
+}
+
+func (r *fake{{.Name}}Repository) DeleteOne(id uint) error {
+	if r.err != nil {
+		return r.err
+	}
+	delete(r.items, id)
+	return nil
+}
+
+func (r *fake{{.Name}}Repository) DeleteMany(ids []uint) error {
+	for _, id := range ids {
+		if err := r.DeleteOne(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fake{{.Name}}Repository) Count() (int64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return int64(len(r.items)), nil
+}
+
+// sample{{.Name}} returns a {{.Name}} whose fields satisfy the DTO binding rules
+func sample{{.Name}}() *models.{{.Name}} {
+	return &models.{{.Name}}{
+{{- range .Fields}}
+		{{.FieldName}}: {{.Sample}},
+{{- end}}
+	}
+}
+
+// valid{{.Name}}Body returns a request body that passes the {{.Name}} DTO validation
+func valid{{.Name}}Body() map[string]any {
+	return map[string]any{
+{{- range .Fields}}
+		"{{.JSONName}}": {{.Sample}},
+{{- end}}
+	}
+}
+
+`
+
+// handlerTestCases - The request helper and test cases shared by the handler tests of every HTTP framework
+const handlerTestCases = `// perform{{.Name}}Request sends a request whose body is either raw text or a value encoded as JSON
+func perform{{.Name}}Request(t *testing.T, r http.Handler, method, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var reader io.Reader = http.NoBody
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reader = strings.NewReader(b)
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			t.Fatalf("failed to encode request body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func Test{{.Name}}Handler(t *testing.T) {
+	errRepository := errors.New("repository unavailable")
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    any
+		repoErr error
+		want    int
+	}{
+		{"index", http.MethodGet, "/{{.KebabPlural}}", nil, nil, http.StatusOK},
+		{"index repository error", http.MethodGet, "/{{.KebabPlural}}", nil, errRepository, http.StatusInternalServerError},
+
+		{"show", http.MethodGet, "/{{.KebabPlural}}/1", nil, nil, http.StatusOK},
+		{"show invalid id", http.MethodGet, "/{{.KebabPlural}}/abc", nil, nil, http.StatusBadRequest},
+		{"show not found", http.MethodGet, "/{{.KebabPlural}}/99", nil, nil, http.StatusNotFound},
+
+		{"create", http.MethodPost, "/{{.KebabPlural}}", valid{{.Name}}Body(), nil, http.StatusCreated},
+		{"create invalid body", http.MethodPost, "/{{.KebabPlural}}", "{", nil, http.StatusBadRequest},
+		{"create repository error", http.MethodPost, "/{{.KebabPlural}}", valid{{.Name}}Body(), errRepository, http.StatusInternalServerError},
+
+		{"update", http.MethodPut, "/{{.KebabPlural}}/1", valid{{.Name}}Body(), nil, http.StatusOK},
+		{"update invalid id", http.MethodPut, "/{{.KebabPlural}}/abc", valid{{.Name}}Body(), nil, http.StatusBadRequest},
+		{"update invalid body", http.MethodPut, "/{{.KebabPlural}}/1", "{", nil, http.StatusBadRequest},
+		{"update repository error", http.MethodPut, "/{{.KebabPlural}}/1", valid{{.Name}}Body(), errRepository, http.StatusInternalServerError},
+
+		{"delete", http.MethodDelete, "/{{.KebabPlural}}/1", nil, nil, http.StatusNoContent},
+		{"delete invalid id", http.MethodDelete, "/{{.KebabPlural}}/abc", nil, nil, http.StatusBadRequest},
+		{"delete repository error", http.MethodDelete, "/{{.KebabPlural}}/1", nil, errRepository, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFake{{.Name}}Repository(sample{{.Name}}())
+			repo.err = tt.repoErr
+
+			w := perform{{.Name}}Request(t, new{{.Name}}TestRouter(repo), tt.method, tt.path, tt.body)
+			if w.Code != tt.want {
+				t.Fatalf("%s %s: got status %d, want %d (body: %s)", tt.method, tt.path, w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func Test{{.Name}}HandlerCreateStoresItem(t *testing.T) {
+	repo := newFake{{.Name}}Repository()
+
+	w := perform{{.Name}}Request(t, new{{.Name}}TestRouter(repo), http.MethodPost, "/{{.KebabPlural}}", valid{{.Name}}Body())
+	if w.Code != http.StatusCreated {
+		t.Fatalf("got status %d, want %d (body: %s)", w.Code, http.StatusCreated, w.Body.String())
+	}
+	if count, _ := repo.Count(); count != 1 {
+		t.Fatalf("repository holds %d items, want 1", count)
+	}
+}
+`
+
+// RepositoryTestTemplate - Template for the repository tests, run against an in-memory SQLite database
+const RepositoryTestTemplate = `package repository
+
+import (
+	"testing"
+{{- if .NeedsTime}}
+	"time"
+{{- end}}
+
+	"{{.Imports.Models}}"
+	"github.com/glebarez/sqlite" // Pure Go SQLite driver, so the tests run without cgo
+	"gorm.io/gorm"
+)
+
+// new{{.Name}}TestDB opens an in-memory SQLite database with the {{.Name}} table migrated
+func new{{.Name}}TestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+
+	// Every connection to ":memory:" gets its own database, so keep a single connection
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get database handle: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.AutoMigrate(&models.{{.Name}}{}); err != nil {
+		t.Fatalf("failed to migrate {{.Name}}: %v", err)
+	}
+	return db
+}
+
+` + repositoryTestCases
+
+// repositoryTestCases are the repository tests shared by every ORM backend
+const repositoryTestCases = `// sample{{.Name}} returns a {{.Name}} with every field set
+func sample{{.Name}}() *models.{{.Name}} {
+	return &models.{{.Name}}{
+{{- range .Fields}}
+		{{.FieldName}}: {{.Sample}},
+{{- end}}
+	}
+}
+
+func Test{{.Name}}Repository(t *testing.T) {
+	repo := New{{.Name}}Repository(new{{.Name}}TestDB(t))
+
+	created, err := repo.Create(sample{{.Name}}())
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID == 0 {
+		t.Fatal("Create: expected an ID to be assigned")
+	}
+
+	found, err := repo.FindByID(created.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if found.ID != created.ID {
+		t.Fatalf("FindByID: got ID %d, want %d", found.ID, created.ID)
+	}
+{{- range .Fields}}{{if .Unique}}
+
+	if _, err := repo.FindBy{{.FieldName}}(created.{{.FieldName}}); err != nil {
+		t.Fatalf("FindBy{{.FieldName}}: %v", err)
+	}
+{{- end}}{{end}}
+
+	all, err := repo.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if len(all) != 1 {
+		t.Fatalf("GetAll: got %d items, want 1", len(all))
+	}
+
+	if _, err := repo.UpdateByID(created.ID, sample{{.Name}}()); err != nil {
+		t.Fatalf("UpdateByID: %v", err)
+	}
+
+	if err := repo.DeleteOne(created.ID); err != nil {
+		t.Fatalf("DeleteOne: %v", err)
+	}
+	if _, err := repo.FindByID(created.ID); err == nil {
+		t.Fatal("FindByID: expected an error after DeleteOne")
+	}
+
+	count, err := repo.Count()
+	if err != nil {
+		t.Fatalf("Count: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("Count: got %d, want 0", count)
+	}
+}
+`
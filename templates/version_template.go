@@ -0,0 +1,33 @@
+package templates
+
+// version_template.go - Template for the package that receives the build information (goi make version)
+
+// VersionTemplate - Template for the version package stamped by 'goi build' through -ldflags -X
+const VersionTemplate = `// Package {{.Package}} holds the build information that 'goi build' stamps into the binary.
+package {{.Package}}
+
+import "fmt"
+
+// Set by 'goi build' with -ldflags "-X {{.ImportPath}}.Version=...".
+// The defaults remain in binaries built with plain 'go build' or 'go run'.
+var (
+	Version   = "dev"     // Latest git tag, or goi build --version
+	Commit    = "none"    // Short SHA of the commit built
+	BuildDate = "unknown" // UTC build time in RFC 3339
+	Dirty     = "false"   // "true" when the working tree had uncommitted changes
+)
+
+// IsDirty reports whether the binary was built from uncommitted changes
+func IsDirty() bool {
+	return Dirty == "true"
+}
+
+// String describes the build, e.g. "v1.4.0 (3f2a9c1, 2026-01-02T15:04:05Z)"
+func String() string {
+	s := fmt.Sprintf("%s (%s, %s)", Version, Commit, BuildDate)
+	if IsDirty() {
+		s += " dirty"
+	}
+	return s
+}
+`
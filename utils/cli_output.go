@@ -40,3 +40,11 @@ func PrintError(message string) {
 	fmt.Fprintln(os.Stderr, colorize("0;31", "ERROR: "+message))
 	os.Exit(1)
 }
+
+// prefixColors are cycled through for the name prefixes of interleaved process output
+var prefixColors = []string{"0;36", "0;33", "0;32", "0;35", "0;34", "1;36", "1;33", "1;32", "1;35"}
+
+// ColorPrefix returns "name |" padded to width and colored with the i-th prefix color
+func ColorPrefix(name string, width, i int) string {
+	return colorize(prefixColors[i%len(prefixColors)], fmt.Sprintf("%-*s |", width, name))
+}
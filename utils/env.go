@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ReadEnvFile parses a .env file into a map.
+// It understands comments, blank lines, an optional "export " prefix and single or double quoted values.
+func ReadEnvFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, lineNumber)
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("%s:%d: empty key", path, lineNumber)
+		}
+		values[key] = unquoteEnvValue(strings.TrimSpace(value))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// unquoteEnvValue strips a trailing " # comment", then the matching quotes of a quoted value
+func unquoteEnvValue(value string) string {
+	if value != "" && (value[0] == '"' || value[0] == '\'') {
+		quote := value[0]
+		for i := 1; i < len(value); i++ {
+			if quote == '"' && value[i] == '\\' {
+				i++
+				continue
+			}
+			if value[i] != quote {
+				continue
+			}
+			// The value ends at its closing quote; only a comment may follow it
+			if rest := strings.TrimSpace(value[i+1:]); rest == "" || strings.HasPrefix(rest, "#") {
+				inner := value[1:i]
+				if quote == '"' {
+					inner = strings.NewReplacer(`\n`, "\n", `\"`, `"`, `\\`, `\`).Replace(inner)
+				}
+				return inner
+			}
+			break
+		}
+	}
+	if strings.HasPrefix(value, "#") {
+		return ""
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value
+}
+
+// LoadEnvFile exports the variables of a .env file into the process environment.
+// Variables that are already set keep their value, so the environment takes precedence over the file.
+func LoadEnvFile(path string) error {
+	values, err := ReadEnvFile(path)
+	if err != nil {
+		return err
+	}
+	for key, value := range values {
+		if _, ok := os.LookupEnv(key); ok {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// SetEnvFileValue sets a variable in a .env file, creating the file if needed.
+// An existing assignment is replaced in place so comments and the order of the other variables are kept.
+func SetEnvFileValue(path, key, value string) error {
+	lines, mode, err := readEnvLines(path)
+	if err != nil {
+		return err
+	}
+
+	assignment := key + "=" + FormatEnvValue(value)
+	var updated []string
+	replaced := false
+	for _, line := range lines {
+		if envLineKey(line) != key {
+			updated = append(updated, line)
+			continue
+		}
+		// Duplicate assignments are dropped, the last of them used to win
+		if !replaced {
+			updated = append(updated, assignment)
+			replaced = true
+		}
+	}
+	if !replaced {
+		updated = append(updated, assignment)
+	}
+	return writeEnvLines(path, updated, mode)
+}
+
+// UnsetEnvFileValue removes a variable from a .env file, reporting whether it was there
+func UnsetEnvFileValue(path, key string) (bool, error) {
+	lines, mode, err := readEnvLines(path)
+	if err != nil {
+		return false, err
+	}
+
+	var kept []string
+	for _, line := range lines {
+		if envLineKey(line) != key {
+			kept = append(kept, line)
+		}
+	}
+	if len(kept) == len(lines) {
+		return false, nil
+	}
+	return true, writeEnvLines(path, kept, mode)
+}
+
+// FormatEnvValue quotes a value when it would not survive ReadEnvFile unquoted
+func FormatEnvValue(value string) string {
+	if value == "" || !strings.ContainsAny(value, " \t\n\"'#\\$`") {
+		return value
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+	return `"` + escaped + `"`
+}
+
+// envLineKey returns the key assigned on a .env line, or "" for blank lines and comments
+func envLineKey(line string) string {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ""
+	}
+	key, _, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(key)
+}
+
+// readEnvLines returns the lines of a .env file and its permissions; a missing file has no lines
+// and gets owner-only permissions since it usually holds secrets
+func readEnvLines(path string) ([]string, os.FileMode, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, 0600, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	content := strings.TrimSuffix(string(data), "\n")
+	if content == "" {
+		return nil, info.Mode().Perm(), nil
+	}
+	return strings.Split(content, "\n"), info.Mode().Perm(), nil
+}
+
+// writeEnvLines writes the lines of a .env file, ending it with a newline
+func writeEnvLines(path string, lines []string, mode os.FileMode) error {
+	content := strings.Join(lines, "\n")
+	if content != "" {
+		content += "\n"
+	}
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
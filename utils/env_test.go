@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadEnvFileValues(t *testing.T) {
+	content := `# comment
+export APP_ENV=production
+PLAIN=abc # comment
+DOUBLE="abc" # comment
+SINGLE='abc' # comment
+HASH="a #b"
+ESCAPED="say \"hi\" # there" # comment
+EMPTY=
+QUOTED_EMPTY=""
+UNCLOSED="abc
+`
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	values, err := ReadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"APP_ENV":      "production",
+		"PLAIN":        "abc",
+		"DOUBLE":       "abc",
+		"SINGLE":       "abc",
+		"HASH":         "a #b",
+		"ESCAPED":      `say "hi" # there`,
+		"EMPTY":        "",
+		"QUOTED_EMPTY": "",
+		"UNCLOSED":     `"abc`,
+	}
+	for key, value := range want {
+		if got, ok := values[key]; !ok || got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+}
+
+func TestFormatEnvValueRoundTrip(t *testing.T) {
+	for _, value := range []string{"", "plain", "with space", `quote "x"`, "hash # x", `back\slash`, "line\nbreak"} {
+		path := filepath.Join(t.TempDir(), ".env")
+		if err := os.WriteFile(path, []byte("KEY="+FormatEnvValue(value)+" # comment\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		values, err := ReadEnvFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if values["KEY"] != value {
+			t.Errorf("round trip of %q gave %q", value, values["KEY"])
+		}
+	}
+}
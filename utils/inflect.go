@@ -0,0 +1,322 @@
+package utils
+
+import (
+	"go/token"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// commonInitialisms are rendered in upper case inside Go identifiers, as golint recommends
+var commonInitialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "CSV": true, "DNS": true,
+	"EOF": true, "GUID": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true,
+	"JSON": true, "JWT": true, "LHS": true, "OTP": true, "QPS": true, "RAM": true, "RHS": true,
+	"RPC": true, "SKU": true, "SLA": true, "SMTP": true, "SQL": true, "SSH": true, "TCP": true,
+	"TLS": true, "TTL": true, "UDP": true, "UI": true, "UID": true, "UUID": true, "URI": true,
+	"URL": true, "UTF8": true, "VM": true, "XML": true, "XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// irregularPlurals maps singular words to plurals that do not follow the rules below
+var irregularPlurals = map[string]string{
+	"person":    "people",
+	"man":       "men",
+	"woman":     "women",
+	"child":     "children",
+	"tooth":     "teeth",
+	"foot":      "feet",
+	"goose":     "geese",
+	"mouse":     "mice",
+	"ox":        "oxen",
+	"leaf":      "leaves",
+	"life":      "lives",
+	"knife":     "knives",
+	"wife":      "wives",
+	"half":      "halves",
+	"shelf":     "shelves",
+	"wolf":      "wolves",
+	"thief":     "thieves",
+	"hero":      "heroes",
+	"potato":    "potatoes",
+	"tomato":    "tomatoes",
+	"echo":      "echoes",
+	"quiz":      "quizzes",
+	"index":     "indices",
+	"matrix":    "matrices",
+	"vertex":    "vertices",
+	"datum":     "data",
+	"medium":    "media",
+	"cactus":    "cacti",
+	"focus":     "foci",
+	"radius":    "radii",
+	"crisis":    "crises",
+	"axis":      "axes",
+	"analysis":  "analyses",
+	"criterion": "criteria",
+	"house":     "houses",
+	"cause":     "causes",
+	"cache":     "caches",
+	"menu":      "menus",
+	"guru":      "gurus",
+	"taxi":      "taxis",
+}
+
+// uncountables have the same singular and plural form
+var uncountables = map[string]bool{
+	"equipment": true, "information": true, "rice": true, "money": true, "species": true,
+	"series": true, "fish": true, "sheep": true, "deer": true, "news": true, "metadata": true,
+	"feedback": true, "staff": true, "software": true, "hardware": true, "inventory": true,
+	"sms": true,
+}
+
+// singularWordsInS end in "s" but are singular; their plural adds "es"
+var singularWordsInS = []string{"alias", "atlas", "bias", "canvas", "gas"}
+
+// singularEnding matches endings of words that are already singular, e.g. "address", "status" or "basis"
+var singularEnding = regexp.MustCompile(`(ss|us|is|` + strings.Join(singularWordsInS, "|") + `)$`)
+
+// inflectionRule rewrites a word ending that matches pattern
+type inflectionRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// pluralRules are applied in order; the first match wins
+var pluralRules = []inflectionRule{
+	{regexp.MustCompile(`(s|x|z|ch|sh)$`), "${1}es"},
+	{regexp.MustCompile(`([^aeiou])y$`), "${1}ies"},
+	{regexp.MustCompile(`$`), "s"},
+}
+
+// singularRules are applied in order; the first match wins
+var singularRules = []inflectionRule{
+	// "movies", "cookies" and "zombies" drop the s; "ties" and "pies" fall through to the last rule
+	{regexp.MustCompile(`(ov|ook|omb)ies$`), "${1}ie"},
+	{regexp.MustCompile(`([a-z][^aeiou])ies$`), "${1}y"},
+	{regexp.MustCompile(`(ss|x|z|ch|sh|us|` + strings.Join(singularWordsInS, "|") + `)es$`), "${1}"},
+	{regexp.MustCompile(`s$`), ""},
+}
+
+// SplitWords breaks an identifier into lower-case words.
+// It understands snake_case, kebab-case, spaces, camelCase and runs of capitals such as "HTTPServer".
+func SplitWords(s string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(s)
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = current[:0]
+		}
+	}
+
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == ' ' || r == '.':
+			flush()
+		case unicode.IsUpper(r):
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			prevUpper := i > 0 && unicode.IsUpper(runes[i-1])
+			if prevUpper && nextLower && isInitialismPlural(current, r, runes[i+1:]) {
+				// "URLs" is the plural of URL, not "UR" followed by "Ls"
+				current = append(current, r)
+				continue
+			}
+			if prevLower || (prevUpper && nextLower) {
+				flush()
+			}
+			current = append(current, r)
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+	return words
+}
+
+// isInitialismPlural reports whether the capitals in current followed by r form an initialism that rest
+// pluralises with a lone "s", e.g. "UR" + 'L' + "s" or "I" + 'D' + "sList"
+func isInitialismPlural(current []rune, r rune, rest []rune) bool {
+	if len(rest) == 0 || rest[0] != 's' || (len(rest) > 1 && unicode.IsLower(rest[1])) {
+		return false
+	}
+	return commonInitialisms[string(current)+string(r)]
+}
+
+// initialismWord reports whether a lower-case word is an initialism or the plural of one, e.g. "url" or "urls"
+func initialismWord(word string) bool {
+	upper := strings.ToUpper(word)
+	return commonInitialisms[upper] || (strings.HasSuffix(word, "s") && commonInitialisms[strings.TrimSuffix(upper, "S")])
+}
+
+// Pascal converts a name to an exported Go identifier, e.g. "order_item" -> "OrderItem", "user_id" -> "UserID"
+func Pascal(s string) string {
+	var b strings.Builder
+	for _, word := range SplitWords(s) {
+		b.WriteString(capitalize(word))
+	}
+	return b.String()
+}
+
+// Camel converts a name to an unexported Go identifier, e.g. "order_item" -> "orderItem", "api_key" -> "apiKey"
+func Camel(s string) string {
+	words := SplitWords(s)
+	var b strings.Builder
+	for i, word := range words {
+		if i == 0 {
+			b.WriteString(word)
+			continue
+		}
+		b.WriteString(capitalize(word))
+	}
+	return b.String()
+}
+
+// VarName converts a name to a camelCase identifier that is never a Go keyword, e.g. "type" -> "typeValue"
+func VarName(s string) string {
+	name := Camel(s)
+	if token.IsKeyword(name) {
+		name += "Value"
+	}
+	return name
+}
+
+// Snake converts a name to snake_case, e.g. "OrderItem" -> "order_item", "APIKey" -> "api_key"
+func Snake(s string) string {
+	return strings.Join(SplitWords(s), "_")
+}
+
+// Kebab converts a name to kebab-case, e.g. "OrderItem" -> "order-item"
+func Kebab(s string) string {
+	return strings.Join(SplitWords(s), "-")
+}
+
+// Plural returns the plural of a name, inflecting only its last word and keeping the input's casing style.
+// "order_item" -> "order_items", "Person" -> "People", "category" -> "categories".
+func Plural(s string) string {
+	return inflectLastWord(s, pluralizeWord)
+}
+
+// Singular returns the singular of a name, inflecting only its last word and keeping the input's casing style.
+// "order_items" -> "order_item", "People" -> "Person", "categories" -> "category".
+func Singular(s string) string {
+	return inflectLastWord(s, singularizeWord)
+}
+
+// TableName returns the conventional database table name for a resource, e.g. "OrderItem" -> "order_items"
+func TableName(s string) string {
+	return Snake(Plural(Snake(s)))
+}
+
+// capitalize upper-cases the first letter of a lower-case word, or the whole word if it is an initialism
+// (keeping the "s" of a plural one in lower case)
+func capitalize(word string) string {
+	if word == "" {
+		return word
+	}
+	if upper := strings.ToUpper(word); commonInitialisms[upper] {
+		return upper
+	}
+	if initialismWord(word) {
+		// "urls" -> "URLs"
+		return strings.ToUpper(strings.TrimSuffix(word, "s")) + "s"
+	}
+	runes := []rune(word)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// inflectLastWord applies fn to the last word of s and splices the result back in with matching case
+func inflectLastWord(s string, fn func(string) string) string {
+	words := SplitWords(s)
+	if len(words) == 0 {
+		return s
+	}
+	last := words[len(words)-1]
+
+	// Locate the last word in the original string to preserve everything before it
+	lower := strings.ToLower(s)
+	start := strings.LastIndex(lower, last)
+	if start < 0 {
+		return s
+	}
+	original := s[start : start+len(last)]
+	inflected := fn(last)
+
+	switch {
+	case commonInitialisms[original]:
+		// Keep initialisms readable: "ID" -> "IDs", not "IDS"
+		inflected = original + strings.TrimPrefix(inflected, last)
+	case original == capitalize(last) && initialismWord(last) && initialismWord(inflected):
+		// "URLs" -> "URL"
+		inflected = capitalize(inflected)
+	case original == strings.ToUpper(original) && len(original) > 1:
+		inflected = strings.ToUpper(inflected)
+	case unicode.IsUpper([]rune(original)[0]):
+		runes := []rune(inflected)
+		runes[0] = unicode.ToUpper(runes[0])
+		inflected = string(runes)
+	}
+	return s[:start] + inflected + s[start+len(last):]
+}
+
+// pluralizeWord returns the plural of a single lower-case word
+func pluralizeWord(word string) string {
+	if uncountables[word] {
+		return word
+	}
+	// Already the plural of an initialism: "urls", "ids"
+	if strings.HasSuffix(word, "s") && initialismWord(word) {
+		return word
+	}
+	if plural, ok := irregularPlurals[word]; ok {
+		return plural
+	}
+	for _, plural := range irregularPlurals {
+		if plural == word {
+			return word
+		}
+	}
+	// Already plural: "boys", "statuses", "keys"
+	if singular := singularizeWord(word); singular != word && pluralizeRules(singular) == word {
+		return word
+	}
+	return pluralizeRules(word)
+}
+
+// pluralizeRules applies the first matching plural rule to a word
+func pluralizeRules(word string) string {
+	for _, rule := range pluralRules {
+		if rule.pattern.MatchString(word) {
+			return rule.pattern.ReplaceAllString(word, rule.replacement)
+		}
+	}
+	return word
+}
+
+// singularizeWord returns the singular of a single lower-case word
+func singularizeWord(word string) string {
+	if uncountables[word] || commonInitialisms[strings.ToUpper(word)] {
+		return word
+	}
+	// The plural of an initialism: "apis" -> "api"
+	if initialismWord(word) {
+		return strings.TrimSuffix(word, "s")
+	}
+	for singular, plural := range irregularPlurals {
+		if plural == word {
+			return singular
+		}
+	}
+	if _, ok := irregularPlurals[word]; ok || singularEnding.MatchString(word) {
+		return word
+	}
+	for _, rule := range singularRules {
+		if rule.pattern.MatchString(word) {
+			return rule.pattern.ReplaceAllString(word, rule.replacement)
+		}
+	}
+	return word
+}
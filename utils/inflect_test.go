@@ -0,0 +1,91 @@
+package utils
+
+import "testing"
+
+func TestInitialismInflections(t *testing.T) {
+	tests := []struct {
+		name                                          string
+		pascal, camel, snake, kebab                   string
+		plural, camelPlural, snakePlural, kebabPlural string
+	}{
+		{"URL", "URL", "url", "url", "url", "URLs", "urls", "urls", "urls"},
+		{"ShortURL", "ShortURL", "shortURL", "short_url", "short-url", "ShortURLs", "shortURLs", "short_urls", "short-urls"},
+		{"API", "API", "api", "api", "api", "APIs", "apis", "apis", "apis"},
+		{"PublicAPI", "PublicAPI", "publicAPI", "public_api", "public-api", "PublicAPIs", "publicAPIs", "public_apis", "public-apis"},
+		{"ID", "ID", "id", "id", "id", "IDs", "ids", "ids", "ids"},
+		{"DeviceID", "DeviceID", "deviceID", "device_id", "device-id", "DeviceIDs", "deviceIDs", "device_ids", "device-ids"},
+		{"HTTP", "HTTP", "http", "http", "http", "HTTPs", "https", "https", "https"},
+		{"HTTPCheck", "HTTPCheck", "httpCheck", "http_check", "http-check", "HTTPChecks", "httpChecks", "http_checks", "http-checks"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plural := Plural(tt.name)
+			got := []string{Pascal(tt.name), Camel(tt.name), Snake(tt.name), Kebab(tt.name), plural, Camel(plural), Snake(plural), Kebab(plural)}
+			want := []string{tt.pascal, tt.camel, tt.snake, tt.kebab, tt.plural, tt.camelPlural, tt.snakePlural, tt.kebabPlural}
+			labels := []string{"Pascal", "Camel", "Snake", "Kebab", "Plural", "Camel(Plural)", "Snake(Plural)", "Kebab(Plural)"}
+			for i := range got {
+				if got[i] != want[i] {
+					t.Errorf("%s(%q) = %q, want %q", labels[i], tt.name, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPluralInitialismRoundTrip(t *testing.T) {
+	tests := []struct{ plural, singular string }{
+		{"URLs", "URL"},
+		{"ShortURLs", "ShortURL"},
+		{"APIs", "API"},
+		{"DeviceIDs", "DeviceID"},
+		{"short_urls", "short_url"},
+	}
+	for _, tt := range tests {
+		if got := Plural(tt.plural); got != tt.plural {
+			t.Errorf("Plural(%q) = %q, want it unchanged", tt.plural, got)
+		}
+		if got := Singular(tt.plural); got != tt.singular {
+			t.Errorf("Singular(%q) = %q, want %q", tt.plural, got, tt.singular)
+		}
+	}
+	if got := Pascal("ShortURLs"); got != "ShortURLs" {
+		t.Errorf("Pascal(%q) = %q, want %q", "ShortURLs", got, "ShortURLs")
+	}
+	if got := Snake("IDsList"); got != "ids_list" {
+		t.Errorf("Snake(%q) = %q, want %q", "IDsList", got, "ids_list")
+	}
+}
+
+func TestInflectWordsEndingInS(t *testing.T) {
+	tests := []struct{ singular, plural string }{
+		{"alias", "aliases"},
+		{"canvas", "canvases"},
+		{"gas", "gases"},
+		{"sms", "sms"},
+		{"status", "statuses"},
+		{"address", "addresses"},
+		{"movie", "movies"},
+		{"cookie", "cookies"},
+		{"tie", "ties"},
+		{"category", "categories"},
+		{"boy", "boys"},
+		{"database", "databases"},
+		{"menu", "menus"},
+		{"api_key", "api_keys"},
+		{"Alias", "Aliases"},
+	}
+	for _, tt := range tests {
+		if got := Singular(tt.singular); got != tt.singular {
+			t.Errorf("Singular(%q) = %q, want it unchanged", tt.singular, got)
+		}
+		if got := Singular(tt.plural); got != tt.singular {
+			t.Errorf("Singular(%q) = %q, want %q", tt.plural, got, tt.singular)
+		}
+		if got := Plural(tt.singular); got != tt.plural {
+			t.Errorf("Plural(%q) = %q, want %q", tt.singular, got, tt.plural)
+		}
+		if got := Plural(tt.plural); got != tt.plural {
+			t.Errorf("Plural(%q) = %q, want it unchanged", tt.plural, got)
+		}
+	}
+}
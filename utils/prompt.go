@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+)
+
+// IsInteractive reports whether both stdin and stdout are terminals, so the user can be asked questions
+func IsInteractive() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) && isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// Choose lists the options with numbers and asks the user to pick one, returning its index
+func Choose(question string, options []string) (int, error) {
+	fmt.Println(colorize("0;34", question))
+	for i, option := range options {
+		fmt.Printf("  %d) %s\n", i+1, option)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Printf("Enter a number [1-%d]: ", len(options))
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return 0, fmt.Errorf("no choice made: %w", err)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(line))
+		if err == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+		PrintWarning(fmt.Sprintf("%q is not a number between 1 and %d", strings.TrimSpace(line), len(options)))
+	}
+}